@@ -0,0 +1,127 @@
+package nilt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+var errMalformedBinary = errors.New("nilt: malformed binary data")
+
+// appendBinary appends a validity byte and, if the value is valid, its payload to b.
+// Integers and durations are encoded as varints, floats as IEEE 754 bits in big-endian order,
+// time as produced by time.Time.MarshalBinary, strings and byte slices as they are.
+func (r ref[T]) appendBinary(b []byte) ([]byte, error) {
+	if !r.ok() {
+		return append(b, 0), nil
+	}
+
+	b = append(b, 1)
+	switch v := any(*r.v).(type) {
+	case string:
+		return append(b, v...), nil
+	case int:
+		return binary.AppendVarint(b, int64(v)), nil
+	case int8:
+		return binary.AppendVarint(b, int64(v)), nil
+	case int16:
+		return binary.AppendVarint(b, int64(v)), nil
+	case int32:
+		return binary.AppendVarint(b, int64(v)), nil
+	case int64:
+		return binary.AppendVarint(b, v), nil
+	case uint:
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case uint8:
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case uint16:
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case uint32:
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case uint64:
+		return binary.AppendUvarint(b, v), nil
+	case float32:
+		return binary.BigEndian.AppendUint32(b, math.Float32bits(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v)), nil
+	case bool:
+		if v {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case time.Time:
+		return v.AppendBinary(b)
+	case time.Duration:
+		return binary.AppendVarint(b, int64(v)), nil
+	case []byte:
+		return append(b, v...), nil
+	}
+
+	return b, nil
+}
+
+// unmarshalBinary decodes data produced by appendBinary.
+// Decoded integers are range checked the same way Scan does.
+func (r ref[T]) unmarshalBinary(data []byte) error {
+	value, err := decodeBinary[T](data)
+	if err != nil {
+		r.reset()
+		return err
+	}
+
+	return r.scan(value)
+}
+
+// decodeBinary decodes data produced by appendBinary into a value accepted by Scan.
+func decodeBinary[T Scalar](data []byte) (interface{}, error) {
+	if len(data) == 0 || data[0] > 1 || (data[0] == 0 && len(data) > 1) {
+		return nil, errMalformedBinary
+	}
+	if data[0] == 0 {
+		return nil, nil
+	}
+
+	data = data[1:]
+
+	var zero T
+	switch any(zero).(type) {
+	case string, []byte:
+		return data, nil
+	case int, int8, int16, int32, int64, time.Duration:
+		v, n := binary.Varint(data)
+		if n <= 0 || n != len(data) {
+			return nil, errMalformedBinary
+		}
+		return v, nil
+	case uint, uint8, uint16, uint32, uint64:
+		v, n := binary.Uvarint(data)
+		if n <= 0 || n != len(data) {
+			return nil, errMalformedBinary
+		}
+		return v, nil
+	case float32:
+		if len(data) != 4 {
+			return nil, errMalformedBinary
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(data)), nil
+	case float64:
+		if len(data) != 8 {
+			return nil, errMalformedBinary
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+	case bool:
+		if len(data) != 1 || data[0] > 1 {
+			return nil, errMalformedBinary
+		}
+		return data[0] == 1, nil
+	case time.Time:
+		var t time.Time
+		if err := t.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+
+	return nil, errMalformedBinary
+}
@@ -0,0 +1,165 @@
+// Package bsonx provides BSON codecs for nilt types, compatible with go.mongodb.org/mongo-driver.
+// It is a separate package, so that the driver is required only by programs that use it.
+//
+// Without the codecs nilt types are encoded as subdocuments holding the value and the validity flag.
+// The codecs encode invalid value as BSON null and valid value as the native BSON type:
+// string, int32 for Int8, Int16, Uint8 and Uint16, int64 for other integers and Duration (in nanoseconds),
+// double for floats, boolean, UTC datetime for Time and binary for Bytes.
+package bsonx
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// NewRegistry returns the default BSON registry with codecs of every nilt type, except generic Of, registered.
+func NewRegistry() *bsoncodec.Registry {
+	reg := bson.NewRegistry()
+	Register(reg)
+
+	return reg
+}
+
+// Register registers codecs of every nilt type, except generic Of, in given registry.
+func Register(reg *bsoncodec.Registry) {
+	for _, v := range []interface{}{
+		nilt.String{},
+		nilt.Int{}, nilt.Int8{}, nilt.Int16{}, nilt.Int32{}, nilt.Int64{},
+		nilt.Uint{}, nilt.Uint8{}, nilt.Uint16{}, nilt.Uint32{}, nilt.Uint64{},
+		nilt.Float32{}, nilt.Float64{},
+		nilt.Bool{},
+		nilt.Time{}, nilt.Duration{}, nilt.Bytes{},
+	} {
+		register(reg, reflect.TypeOf(v))
+	}
+}
+
+// RegisterOf registers codec of nilt.Of[T] in given registry.
+func RegisterOf[T nilt.Scalar](reg *bsoncodec.Registry) {
+	register(reg, reflect.TypeOf(nilt.Of[T]{}))
+}
+
+func register(reg *bsoncodec.Registry, typ reflect.Type) {
+	reg.RegisterTypeEncoder(typ, bsoncodec.ValueEncoderFunc(encode))
+	reg.RegisterTypeDecoder(typ, bsoncodec.ValueDecoderFunc(decode))
+}
+
+// encode writes v, one of nilt types, as BSON null if it is invalid,
+// otherwise as the BSON type its value maps to, see the package documentation.
+func encode(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, v reflect.Value) error {
+	if !v.CanAddr() {
+		// Value of a map or of a struct passed by value is not addressable, Unwrap is given pointer to its copy.
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	value, valid, ok := nilt.Unwrap(v.Addr().Interface())
+	if !ok {
+		return bsoncodec.ValueEncoderError{Name: "bsonx.encode", Received: v}
+	}
+	if !valid {
+		return vw.WriteNull()
+	}
+
+	switch f := reflect.ValueOf(value); f.Kind() {
+	case reflect.String:
+		return vw.WriteString(f.String())
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return vw.WriteInt32(int32(f.Int()))
+	case reflect.Int, reflect.Int64:
+		return vw.WriteInt64(f.Int())
+	case reflect.Uint8, reflect.Uint16:
+		return vw.WriteInt32(int32(f.Uint()))
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		if f.Uint() > math.MaxInt64 {
+			return fmt.Errorf("bsonx: value %d of %s does not fit into int64", f.Uint(), v.Type())
+		}
+		return vw.WriteInt64(int64(f.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return vw.WriteDouble(f.Float())
+	case reflect.Bool:
+		return vw.WriteBoolean(f.Bool())
+	case reflect.Slice:
+		return vw.WriteBinary(f.Bytes())
+	}
+	if t, ok := value.(time.Time); ok {
+		return vw.WriteDateTime(t.UnixMilli())
+	}
+
+	return bsoncodec.ValueEncoderError{Name: "bsonx.encode", Received: v}
+}
+
+// decode reads BSON null, undefined or a value of any BSON type listed in the package documentation
+// and passes it to Scan method of v, which converts it and validates its range.
+func decode(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, v reflect.Value) error {
+	if !v.CanAddr() {
+		return bsoncodec.ValueDecoderError{Name: "bsonx.decode", Received: v}
+	}
+	p := v.Addr().Interface()
+	current, _, ok := nilt.Unwrap(p)
+	if !ok {
+		return bsoncodec.ValueDecoderError{Name: "bsonx.decode", Received: v}
+	}
+	s := p.(sql.Scanner)
+
+	var (
+		value interface{}
+		err   error
+	)
+	switch vr.Type() {
+	case bsontype.Null:
+		err = vr.ReadNull()
+	case bsontype.Undefined:
+		err = vr.ReadUndefined()
+	case bsontype.String:
+		value, err = vr.ReadString()
+	case bsontype.Int32:
+		var i int32
+		i, err = vr.ReadInt32()
+		value = integer(current, int64(i))
+	case bsontype.Int64:
+		var i int64
+		i, err = vr.ReadInt64()
+		value = integer(current, i)
+	case bsontype.Double:
+		value, err = vr.ReadDouble()
+	case bsontype.Boolean:
+		value, err = vr.ReadBoolean()
+	case bsontype.DateTime:
+		var ms int64
+		ms, err = vr.ReadDateTime()
+		value = time.UnixMilli(ms).UTC()
+	case bsontype.Binary:
+		value, _, err = vr.ReadBinary()
+	default:
+		return fmt.Errorf("bsonx: cannot decode %s into %s", vr.Type(), v.Type())
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = s.Scan(value); err != nil {
+		return fmt.Errorf("bsonx: cannot decode %s into %s: %w", vr.Type(), v.Type(), err)
+	}
+	return nil
+}
+
+// integer converts i into float64 if current value of the destination is a float,
+// so integers can be decoded into floats.
+func integer(current interface{}, i int64) interface{} {
+	switch current.(type) {
+	case float32, float64:
+		return float64(i)
+	}
+
+	return i
+}
@@ -0,0 +1,146 @@
+package bsonx_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/bsonx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var registry = bsonx.NewRegistry()
+
+func init() {
+	bsonx.RegisterOf[int64](registry)
+}
+
+type record struct {
+	Name    nilt.String
+	Count   nilt.Int64
+	Small   nilt.Int8
+	Big     nilt.Uint64
+	Ratio   nilt.Float32
+	Score   nilt.Float64
+	Active  nilt.Bool
+	Created nilt.Time
+	Timeout nilt.Duration
+	Payload nilt.Bytes
+	Limit   nilt.Of[int64]
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]record{
+		"valid": {
+			Name:    nilt.String{String: "text", Valid: true},
+			Count:   nilt.Int64{Int64: math.MinInt64, Valid: true},
+			Small:   nilt.Int8{Int8: -1, Valid: true},
+			Big:     nilt.Uint64{Uint64: math.MaxInt64, Valid: true},
+			Ratio:   nilt.Float32{Float32: 1.5, Valid: true},
+			Score:   nilt.Float64{Float64: 0, Valid: true},
+			Active:  nilt.Bool{Bool: false, Valid: true},
+			Created: nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 123000000, time.UTC), Valid: true},
+			Timeout: nilt.Duration{Duration: time.Minute, Valid: true},
+			Payload: nilt.Bytes{Bytes: []byte{1, 2}, Valid: true},
+			Limit:   nilt.Of[int64]{V: 5, Valid: true},
+		},
+		"invalid": {},
+	}
+
+	for d, given := range cases {
+		b, err := bson.MarshalWithRegistry(registry, given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		var got record
+		if err = bson.UnmarshalWithRegistry(registry, b, &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, given) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, given, got)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	cases := map[string]struct {
+		given    interface{}
+		expected bsontype.Type
+	}{
+		"invalid":  {given: nilt.Int64{Int64: 1}, expected: bsontype.Null},
+		"string":   {given: nilt.String{String: "text", Valid: true}, expected: bsontype.String},
+		"int8":     {given: nilt.Int8{Int8: 1, Valid: true}, expected: bsontype.Int32},
+		"int32":    {given: nilt.Int32{Int32: 1, Valid: true}, expected: bsontype.Int32},
+		"uint16":   {given: nilt.Uint16{Uint16: 1, Valid: true}, expected: bsontype.Int32},
+		"int":      {given: nilt.Int{Int: 1, Valid: true}, expected: bsontype.Int64},
+		"uint32":   {given: nilt.Uint32{Uint32: 1, Valid: true}, expected: bsontype.Int64},
+		"float32":  {given: nilt.Float32{Float32: 1, Valid: true}, expected: bsontype.Double},
+		"bool":     {given: nilt.Bool{Bool: true, Valid: true}, expected: bsontype.Boolean},
+		"time":     {given: nilt.Time{Time: time.Unix(1, 0), Valid: true}, expected: bsontype.DateTime},
+		"duration": {given: nilt.Duration{Duration: time.Second, Valid: true}, expected: bsontype.Int64},
+		"bytes":    {given: nilt.Bytes{Bytes: []byte("text"), Valid: true}, expected: bsontype.Binary},
+	}
+
+	for d, c := range cases {
+		b, err := bson.MarshalWithRegistry(registry, bson.M{"v": c.given})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if got := bson.Raw(b).Lookup("v").Type; got != c.expected {
+			t.Errorf("%s: wrong type, expected %s but got %s", d, c.expected, got)
+		}
+	}
+}
+
+func TestEncode_uint64Overflow(t *testing.T) {
+	_, err := bson.MarshalWithRegistry(registry, bson.M{"v": nilt.Uint64{Uint64: math.MaxUint64, Valid: true}})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var got struct {
+		Ratio nilt.Float64
+		Count nilt.Int64
+		Name  nilt.String
+	}
+	b, err := bson.Marshal(bson.M{"ratio": int32(2), "count": int32(3), "name": nil})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	got.Name = nilt.String{String: "text", Valid: true}
+	if err = bson.UnmarshalWithRegistry(registry, b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if got.Ratio != (nilt.Float64{Float64: 2, Valid: true}) {
+		t.Errorf("wrong ratio, got %#v", got.Ratio)
+	}
+	if got.Count != (nilt.Int64{Int64: 3, Valid: true}) {
+		t.Errorf("wrong count, got %#v", got.Count)
+	}
+	if got.Name.Valid {
+		t.Errorf("expected invalid name, got %#v", got.Name)
+	}
+}
+
+func TestDecode_outOfRange(t *testing.T) {
+	var got struct {
+		Small nilt.Int8
+	}
+	b, err := bson.Marshal(bson.M{"small": int32(300)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err = bson.UnmarshalWithRegistry(registry, b, &got); err == nil {
+		t.Error("expected error")
+	}
+}
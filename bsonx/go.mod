@@ -0,0 +1,12 @@
+module github.com/piotrkowalczuk/nilt/bsonx
+
+go 1.24
+
+require (
+	github.com/piotrkowalczuk/nilt v0.0.0
+	go.mongodb.org/mongo-driver v1.17.6
+)
+
+require google.golang.org/protobuf v1.36.9 // indirect
+
+replace github.com/piotrkowalczuk/nilt => ../
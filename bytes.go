@@ -0,0 +1,140 @@
+package nilt
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Bytes represents a []byte that may be nil.
+// Unlike plain []byte, empty slice and null are distinguished by the Valid flag.
+type Bytes struct {
+	Bytes []byte `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	Valid bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (b *Bytes) Reset() { *b = Bytes{} }
+
+// String implements proto.Message interface.
+func (b *Bytes) String() string { return compactText(b.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Bytes) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (b *Bytes) ProtoReflect() protoreflect.Message { return bytesType.message(b) }
+
+func (b *Bytes) ref() ref[[]byte] {
+	if b == nil {
+		return ref[[]byte]{}
+	}
+	return ref[[]byte]{v: &b.Bytes, valid: &b.Valid}
+}
+
+func (b *Bytes) unwrap() (interface{}, bool) {
+	return b.ref().unwrap()
+}
+
+// BytesOr returns given []byte value if receiver is nil or invalid.
+func (b *Bytes) BytesOr(or []byte) []byte {
+	return b.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (b Bytes) Value() (driver.Value, error) {
+	return b.ref().value()
+}
+
+// Scan implements the Scanner interface.
+// Slice passed by the driver is copied, it is safe to keep it after the next call to Rows.Next.
+func (b *Bytes) Scan(value interface{}) error {
+	return b.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Valid value is encoded as base64 string.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return b.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	return b.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (b Bytes) AppendJSON(dst []byte) ([]byte, error) {
+	return b.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (b Bytes) IsZero() bool {
+	return b.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Valid value is encoded using standard base64 encoding and invalid value as empty text,
+// so valid empty slice is decoded back by UnmarshalText as invalid.
+func (b Bytes) MarshalText() ([]byte, error) {
+	return b.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	return b.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (b Bytes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return b.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (b *Bytes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return b.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (b Bytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return b.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (b *Bytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (b Bytes) MarshalBinary() ([]byte, error) {
+	return b.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (b Bytes) AppendBinary(dst []byte) ([]byte, error) {
+	return b.ref().appendBinary(dst)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (b *Bytes) UnmarshalBinary(data []byte) error {
+	return b.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (b Bytes) GobEncode() ([]byte, error) {
+	return b.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (b *Bytes) GobDecode(data []byte) error {
+	return b.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (b *Bytes) Appear() bool {
+	return b.ref().appear()
+}
@@ -0,0 +1,374 @@
+// Package cborx provides CBOR support for nilt types,
+// compatible with github.com/fxamacker/cbor/v2.
+// It is a separate package, so that the cbor module is required only by programs that use it.
+//
+// Each type of the package embeds its nilt counterpart and implements cbor.Marshaler and cbor.Unmarshaler.
+// Invalid value is encoded as null and valid value as the native CBOR item of its type,
+// integers and floats keep the width of the Go type and time is encoded as RFC3339 string with tag 0.
+//
+// CBOR undefined is decoded as invalid value with Undefined flag set,
+// which lets to tell absent values from null ones. Invalid value with Undefined flag is encoded as undefined.
+package cborx
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/piotrkowalczuk/nilt"
+)
+
+// Simple values of null and undefined.
+const (
+	null      = 0xf6
+	undefined = 0xf7
+)
+
+var (
+	encMode cbor.EncMode
+	decMode cbor.DecMode
+)
+
+func init() {
+	var err error
+	encMode, err = cbor.EncOptions{
+		Time:    cbor.TimeRFC3339Nano,
+		TimeTag: cbor.EncTagRequired,
+	}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	decMode, err = cbor.DecOptions{}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Of wraps nilt.Of with CBOR support.
+type Of[T nilt.Scalar] struct {
+	nilt.Of[T]
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (o Of[T]) MarshalCBOR() ([]byte, error) {
+	return marshal(o.V, o.Valid, o.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (o *Of[T]) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &o.V, &o.Valid, &o.Undefined)
+}
+
+// String wraps nilt.String with CBOR support.
+type String struct {
+	nilt.String
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (s String) MarshalCBOR() ([]byte, error) {
+	return marshal(s.String.String, s.Valid, s.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (s *String) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &s.String.String, &s.Valid, &s.Undefined)
+}
+
+// Int wraps nilt.Int with CBOR support.
+type Int struct {
+	nilt.Int
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (i Int) MarshalCBOR() ([]byte, error) {
+	return marshal(i.Int.Int, i.Valid, i.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (i *Int) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &i.Int.Int, &i.Valid, &i.Undefined)
+}
+
+// Int8 wraps nilt.Int8 with CBOR support.
+type Int8 struct {
+	nilt.Int8
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (i Int8) MarshalCBOR() ([]byte, error) {
+	return marshal(i.Int8.Int8, i.Valid, i.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (i *Int8) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &i.Int8.Int8, &i.Valid, &i.Undefined)
+}
+
+// Int16 wraps nilt.Int16 with CBOR support.
+type Int16 struct {
+	nilt.Int16
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (i Int16) MarshalCBOR() ([]byte, error) {
+	return marshal(i.Int16.Int16, i.Valid, i.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (i *Int16) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &i.Int16.Int16, &i.Valid, &i.Undefined)
+}
+
+// Int32 wraps nilt.Int32 with CBOR support.
+type Int32 struct {
+	nilt.Int32
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (i Int32) MarshalCBOR() ([]byte, error) {
+	return marshal(i.Int32.Int32, i.Valid, i.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (i *Int32) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &i.Int32.Int32, &i.Valid, &i.Undefined)
+}
+
+// Int64 wraps nilt.Int64 with CBOR support.
+type Int64 struct {
+	nilt.Int64
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (i Int64) MarshalCBOR() ([]byte, error) {
+	return marshal(i.Int64.Int64, i.Valid, i.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (i *Int64) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &i.Int64.Int64, &i.Valid, &i.Undefined)
+}
+
+// Uint wraps nilt.Uint with CBOR support.
+type Uint struct {
+	nilt.Uint
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (u Uint) MarshalCBOR() ([]byte, error) {
+	return marshal(u.Uint.Uint, u.Valid, u.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (u *Uint) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &u.Uint.Uint, &u.Valid, &u.Undefined)
+}
+
+// Uint8 wraps nilt.Uint8 with CBOR support.
+type Uint8 struct {
+	nilt.Uint8
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (u Uint8) MarshalCBOR() ([]byte, error) {
+	return marshal(u.Uint8.Uint8, u.Valid, u.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (u *Uint8) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &u.Uint8.Uint8, &u.Valid, &u.Undefined)
+}
+
+// Uint16 wraps nilt.Uint16 with CBOR support.
+type Uint16 struct {
+	nilt.Uint16
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (u Uint16) MarshalCBOR() ([]byte, error) {
+	return marshal(u.Uint16.Uint16, u.Valid, u.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (u *Uint16) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &u.Uint16.Uint16, &u.Valid, &u.Undefined)
+}
+
+// Uint32 wraps nilt.Uint32 with CBOR support.
+type Uint32 struct {
+	nilt.Uint32
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (u Uint32) MarshalCBOR() ([]byte, error) {
+	return marshal(u.Uint32.Uint32, u.Valid, u.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (u *Uint32) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &u.Uint32.Uint32, &u.Valid, &u.Undefined)
+}
+
+// Uint64 wraps nilt.Uint64 with CBOR support.
+type Uint64 struct {
+	nilt.Uint64
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (u Uint64) MarshalCBOR() ([]byte, error) {
+	return marshal(u.Uint64.Uint64, u.Valid, u.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &u.Uint64.Uint64, &u.Valid, &u.Undefined)
+}
+
+// Float32 wraps nilt.Float32 with CBOR support.
+type Float32 struct {
+	nilt.Float32
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (f Float32) MarshalCBOR() ([]byte, error) {
+	return marshal(f.Float32.Float32, f.Valid, f.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (f *Float32) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &f.Float32.Float32, &f.Valid, &f.Undefined)
+}
+
+// Float64 wraps nilt.Float64 with CBOR support.
+type Float64 struct {
+	nilt.Float64
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (f Float64) MarshalCBOR() ([]byte, error) {
+	return marshal(f.Float64.Float64, f.Valid, f.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &f.Float64.Float64, &f.Valid, &f.Undefined)
+}
+
+// Bool wraps nilt.Bool with CBOR support.
+type Bool struct {
+	nilt.Bool
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	return marshal(b.Bool.Bool, b.Valid, b.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &b.Bool.Bool, &b.Valid, &b.Undefined)
+}
+
+// Time wraps nilt.Time with CBOR support.
+type Time struct {
+	nilt.Time
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (t Time) MarshalCBOR() ([]byte, error) {
+	return marshal(t.Time.Time, t.Valid, t.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &t.Time.Time, &t.Valid, &t.Undefined)
+}
+
+// Duration wraps nilt.Duration with CBOR support.
+type Duration struct {
+	nilt.Duration
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	return marshal(d.Duration.Duration, d.Valid, d.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (d *Duration) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &d.Duration.Duration, &d.Valid, &d.Undefined)
+}
+
+// Bytes wraps nilt.Bytes with CBOR support.
+type Bytes struct {
+	nilt.Bytes
+	// Undefined tells if invalid value is undefined rather than null.
+	Undefined bool
+}
+
+// MarshalCBOR implements cbor.Marshaler interface.
+func (b Bytes) MarshalCBOR() ([]byte, error) {
+	return marshal(b.Bytes.Bytes, b.Valid, b.Undefined)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler interface.
+func (b *Bytes) UnmarshalCBOR(data []byte) error {
+	return unmarshal(data, &b.Bytes.Bytes, &b.Valid, &b.Undefined)
+}
+
+func marshal[T any](v T, valid, isUndefined bool) ([]byte, error) {
+	if !valid {
+		if isUndefined {
+			return []byte{undefined}, nil
+		}
+		return []byte{null}, nil
+	}
+
+	return encMode.Marshal(v)
+}
+
+func unmarshal[T any](data []byte, v *T, valid, isUndefined *bool) error {
+	var tmp T
+	if len(data) == 1 && (data[0] == null || data[0] == undefined) {
+		*v, *valid, *isUndefined = tmp, false, data[0] == undefined
+		return nil
+	}
+
+	if err := decMode.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	*v, *valid, *isUndefined = tmp, true, false
+	return nil
+}
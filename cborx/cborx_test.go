@@ -0,0 +1,106 @@
+package cborx_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/cborx"
+)
+
+type reading struct {
+	Device  cborx.String    `cbor:"device"`
+	Counter cborx.Uint32    `cbor:"counter"`
+	Delta   cborx.Int32     `cbor:"delta"`
+	Value   cborx.Float32   `cbor:"value"`
+	Battery cborx.Of[uint8] `cbor:"battery"`
+	At      cborx.Time      `cbor:"at"`
+	Raw     cborx.Bytes     `cbor:"raw"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]reading{
+		"valid": {
+			Device:  cborx.String{String: nilt.String{String: "sensor", Valid: true}},
+			Counter: cborx.Uint32{Uint32: nilt.Uint32{Uint32: math.MaxUint32, Valid: true}},
+			Delta:   cborx.Int32{Int32: nilt.Int32{Int32: math.MinInt32, Valid: true}},
+			Value:   cborx.Float32{Float32: nilt.Float32{Float32: 1.1, Valid: true}},
+			Battery: cborx.Of[uint8]{Of: nilt.Of[uint8]{V: 0, Valid: true}},
+			At:      cborx.Time{Time: nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC), Valid: true}},
+			Raw:     cborx.Bytes{Bytes: nilt.Bytes{Bytes: []byte{0, 1}, Valid: true}},
+		},
+		"null": {},
+		"undefined": {
+			Device:  cborx.String{Undefined: true},
+			Battery: cborx.Of[uint8]{Undefined: true},
+		},
+	}
+
+	for d, given := range cases {
+		b, err := cbor.Marshal(given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		var got reading
+		if err = cbor.Unmarshal(b, &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, given) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, given, got)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	cases := map[string]struct {
+		given    interface{}
+		expected []byte
+	}{
+		"null":      {given: cborx.Int32{}, expected: []byte{0xf6}},
+		"undefined": {given: cborx.Int32{Undefined: true}, expected: []byte{0xf7}},
+		"uint32":    {given: cborx.Uint32{Uint32: nilt.Uint32{Uint32: math.MaxUint32, Valid: true}}, expected: []byte{0x1a, 0xff, 0xff, 0xff, 0xff}},
+		"int32":     {given: cborx.Int32{Int32: nilt.Int32{Int32: -1, Valid: true}}, expected: []byte{0x20}},
+		"float32":   {given: cborx.Float32{Float32: nilt.Float32{Float32: 1.5, Valid: true}}, expected: []byte{0xfa, 0x3f, 0xc0, 0x00, 0x00}},
+		"time":      {given: cborx.Time{Time: nilt.Time{Time: time.Unix(0, 0).UTC(), Valid: true}}, expected: append([]byte{0xc0, 0x74}, "1970-01-01T00:00:00Z"...)},
+	}
+
+	for d, c := range cases {
+		b, err := cbor.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !bytes.Equal(b, c.expected) {
+			t.Errorf("%s: wrong output, expected %x but got %x", d, c.expected, b)
+		}
+	}
+}
+
+func TestUnmarshal_range(t *testing.T) {
+	cases := map[string]struct {
+		given interface{}
+		into  interface{}
+	}{
+		"uint32 overflow": {given: uint64(math.MaxUint32 + 1), into: &cborx.Uint32{}},
+		"int32 overflow":  {given: int64(math.MinInt32 - 1), into: &cborx.Int32{}},
+		"negative uint":   {given: -1, into: &cborx.Uint32{}},
+		"string into int": {given: "1", into: &cborx.Int64{}},
+	}
+
+	for d, c := range cases {
+		b, err := cbor.Marshal(c.given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", d, err.Error())
+		}
+		if err = cbor.Unmarshal(b, c.into); err == nil {
+			t.Errorf("%s: expected error", d)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/piotrkowalczuk/nilt/cborx
+
+go 1.24
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.2
+	github.com/piotrkowalczuk/nilt v0.0.0
+)
+
+require (
+	github.com/x448/float16 v0.8.4 // indirect
+	google.golang.org/protobuf v1.36.9 // indirect
+)
+
+replace github.com/piotrkowalczuk/nilt => ../
@@ -0,0 +1,171 @@
+// Command protoc-gen-go-nilt is a protoc plugin that generates accessors of nilt types
+// next to the code generated by protoc-gen-go.
+//
+// For every singular field of a well-known wrapper type (e.g. google.protobuf.StringValue),
+// google.protobuf.Timestamp, google.protobuf.Duration or a proto3 optional scalar type
+// it generates a pair of methods, e.g. for a field name:
+//
+//	func (x *User) GetNameNilt() nilt.String
+//	func (x *User) SetNameNilt(v nilt.String)
+//
+// Unset field maps to invalid value and vice versa. Fields of other types are ignored.
+// The code is written into a file with _nilt.pb.go suffix, only if the source file has any such field:
+//
+//	protoc --go_out=. --go-nilt_out=. user.proto
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const version = "1.0.0"
+
+const niltPackage = protogen.GoImportPath("github.com/piotrkowalczuk/nilt")
+
+// wrappers maps message types to the nilt types holding their value.
+var wrappers = map[protoreflect.FullName]string{
+	"google.protobuf.StringValue": "String",
+	"google.protobuf.Int32Value":  "Int32",
+	"google.protobuf.Int64Value":  "Int64",
+	"google.protobuf.UInt32Value": "Uint32",
+	"google.protobuf.UInt64Value": "Uint64",
+	"google.protobuf.FloatValue":  "Float32",
+	"google.protobuf.DoubleValue": "Float64",
+	"google.protobuf.BoolValue":   "Bool",
+	"google.protobuf.BytesValue":  "Bytes",
+	"google.protobuf.Timestamp":   "Time",
+	"google.protobuf.Duration":    "Duration",
+}
+
+// scalars maps kinds of proto3 optional fields to the nilt types holding their value.
+// Optional bytes field is not a pointer, therefore it is not supported.
+var scalars = map[protoreflect.Kind]string{
+	protoreflect.StringKind:   "String",
+	protoreflect.Int32Kind:    "Int32",
+	protoreflect.Sint32Kind:   "Int32",
+	protoreflect.Sfixed32Kind: "Int32",
+	protoreflect.Int64Kind:    "Int64",
+	protoreflect.Sint64Kind:   "Int64",
+	protoreflect.Sfixed64Kind: "Int64",
+	protoreflect.Uint32Kind:   "Uint32",
+	protoreflect.Fixed32Kind:  "Uint32",
+	protoreflect.Uint64Kind:   "Uint64",
+	protoreflect.Fixed64Kind:  "Uint64",
+	protoreflect.FloatKind:    "Float32",
+	protoreflect.DoubleKind:   "Float64",
+	protoreflect.BoolKind:     "Bool",
+}
+
+func main() {
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+	if *showVersion {
+		fmt.Printf("protoc-gen-go-nilt %s\n", version)
+		return
+	}
+
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
+
+// generateFile generates accessors for messages of given file, it returns nil if there is nothing to generate.
+func generateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	var fields []*protogen.Field
+	for _, m := range messages(file.Messages) {
+		for _, f := range m.Fields {
+			if _, ok := niltType(f); ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_nilt.pb.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-nilt. DO NOT EDIT.")
+	g.P("// versions:")
+	g.P("// \tprotoc-gen-go-nilt v", version)
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+
+	for _, f := range fields {
+		generateField(g, f)
+	}
+
+	return g
+}
+
+func generateField(g *protogen.GeneratedFile, f *protogen.Field) {
+	name, _ := niltType(f)
+	typ := g.QualifiedGoIdent(niltPackage.Ident(name))
+	msg := f.Parent.GoIdent.GoName
+	// Message fields are pointers to the wrapper types, even if they have the optional keyword.
+	ptr := f.Message == nil && f.Desc.HasOptionalKeyword()
+
+	g.P()
+	g.P("// Get", f.GoName, "Nilt returns value of ", f.Desc.Name(), " field as ", typ, ".")
+	g.P("func (x *", msg, ") Get", f.GoName, "Nilt() ", typ, " {")
+	if ptr {
+		g.P("if x == nil {")
+		g.P("return ", typ, "{}")
+		g.P("}")
+		g.P("return ", g.QualifiedGoIdent(niltPackage.Ident(name+"FromPtr")), "(x.", f.GoName, ")")
+	} else {
+		g.P("return ", g.QualifiedGoIdent(niltPackage.Ident(name+"FromProto")), "(x.Get", f.GoName, "())")
+	}
+	g.P("}")
+	g.P()
+	g.P("// Set", f.GoName, "Nilt sets ", f.Desc.Name(), " field to given value, invalid value clears the field.")
+	g.P("func (x *", msg, ") Set", f.GoName, "Nilt(v ", typ, ") {")
+	if ptr {
+		g.P("x.", f.GoName, " = v.Ptr()")
+	} else {
+		g.P("x.", f.GoName, " = v.ToProto()")
+	}
+	g.P("}")
+}
+
+// niltType returns name of the nilt type that corresponds to given field.
+func niltType(f *protogen.Field) (string, bool) {
+	if f.Desc.IsList() || f.Desc.IsMap() || (f.Oneof != nil && !f.Oneof.Desc.IsSynthetic()) {
+		return "", false
+	}
+	if f.Message != nil {
+		name, ok := wrappers[f.Message.Desc.FullName()]
+		return name, ok
+	}
+	if f.Desc.HasOptionalKeyword() {
+		name, ok := scalars[f.Desc.Kind()]
+		return name, ok
+	}
+
+	return "", false
+}
+
+// messages returns given messages and all messages nested in them, except map entries.
+func messages(ms []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range ms {
+		if m.Desc.IsMapEntry() {
+			continue
+		}
+		all = append(all, m)
+		all = append(all, messages(m.Messages)...)
+	}
+
+	return all
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// TestGenerateFile describes the messages of testdata/user.proto.
+func TestGenerateFile(t *testing.T) {
+	given := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("user.proto"),
+		Package:    proto.String("example"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto", "google/protobuf/timestamp.proto"},
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("example.com/example;example")},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				field("created_at", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				optional(field("age", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""), 0),
+				field("id", 4, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				repeated(field("aliases", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue")),
+				optional(field("avatar", 6, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""), 1),
+				optional(field("nickname", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"), 2),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("city", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				},
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{
+				{Name: proto.String("_age")},
+				{Name: proto.String("_avatar")},
+				{Name: proto.String("_nickname")},
+			},
+		}},
+	}
+
+	files := generate(t, given)
+	got := files["example.com/example/user_nilt.pb.go"]
+	expected := []string{
+		"// source: user.proto",
+		"package example",
+		`nilt "github.com/piotrkowalczuk/nilt"`,
+		"func (x *User) GetNameNilt() nilt.String {\n\treturn nilt.StringFromProto(x.GetName())\n}",
+		"func (x *User) SetNameNilt(v nilt.String) {\n\tx.Name = v.ToProto()\n}",
+		"func (x *User) GetCreatedAtNilt() nilt.Time {",
+		"func (x *User) SetCreatedAtNilt(v nilt.Time) {",
+		"return nilt.Int32FromPtr(x.Age)",
+		"x.Age = v.Ptr()",
+		"return nilt.StringFromProto(x.GetNickname())",
+		"x.Nickname = v.ToProto()",
+		"func (x *User_Address) GetCityNilt() nilt.String {",
+	}
+	for _, e := range expected {
+		if !strings.Contains(got, e) {
+			t.Errorf("expected generated code to contain %q, got:\n%s", e, got)
+		}
+	}
+	for _, e := range []string{"IdNilt", "AliasesNilt", "AvatarNilt"} {
+		if strings.Contains(got, e) {
+			t.Errorf("expected generated code not to contain %q, got:\n%s", e, got)
+		}
+	}
+	typeCheck(t, got, "testdata/user.pb.go")
+}
+
+func TestGenerateFile_nothing(t *testing.T) {
+	given := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("plain.proto"),
+		Package: proto.String("example"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/example;example")},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Plain"),
+			Field: []*descriptorpb.FieldDescriptorProto{field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, "")},
+		}},
+	}
+
+	if got, ok := generate(t, given)["example.com/example/plain_nilt.pb.go"]; ok {
+		t.Errorf("expected no file to be generated, got:\n%s", got)
+	}
+}
+
+// generate runs the plugin for given file and returns content of the generated files by name.
+func generate(t *testing.T, file *descriptorpb.FileDescriptorProto) map[string]string {
+	t.Helper()
+
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+			file,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f)
+		}
+	}
+
+	res := gen.Response()
+	if res.Error != nil {
+		t.Fatalf("unexpected error: %s", res.GetError())
+	}
+	files := make(map[string]string, len(res.File))
+	for _, f := range res.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files
+}
+
+// typeCheck type-checks generated code as a single package with given file generated by protoc-gen-go,
+// imported packages are loaded from source.
+func typeCheck(t *testing.T, generated, pb string) {
+	t.Helper()
+
+	content, err := os.ReadFile(pb)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for name, content := range map[string]string{"generated.go": generated, pb: string(content)} {
+		f, err := parser.ParseFile(fset, name, content, 0)
+		if err != nil {
+			t.Fatalf("%s: code is not valid: %s", name, err.Error())
+		}
+		parsed = append(parsed, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("example.com/example", fset, parsed, nil); err != nil {
+		t.Errorf("generated code does not compile: %s", err.Error())
+	}
+}
+
+func field(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(num),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func optional(f *descriptorpb.FieldDescriptorProto, oneof int32) *descriptorpb.FieldDescriptorProto {
+	f.Proto3Optional = proto.Bool(true)
+	f.OneofIndex = proto.Int32(oneof)
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
@@ -0,0 +1,238 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: user.proto
+
+package example
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Name          *wrapperspb.StringValue   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp    `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Age           *int32                    `protobuf:"varint,3,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	Id            int64                     `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
+	Aliases       []*wrapperspb.StringValue `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Avatar        []byte                    `protobuf:"bytes,6,opt,name=avatar,proto3,oneof" json:"avatar,omitempty"`
+	Nickname      *wrapperspb.StringValue   `protobuf:"bytes,7,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetAliases() []*wrapperspb.StringValue {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *User) GetAvatar() []byte {
+	if x != nil {
+		return x.Avatar
+	}
+	return nil
+}
+
+func (x *User) GetNickname() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Nickname
+	}
+	return nil
+}
+
+type User_Address struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	City          *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User_Address) Reset() {
+	*x = User_Address{}
+	mi := &file_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User_Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User_Address) ProtoMessage() {}
+
+func (x *User_Address) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User_Address.ProtoReflect.Descriptor instead.
+func (*User_Address) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *User_Address) GetCity() *wrapperspb.StringValue {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+var File_user_proto protoreflect.FileDescriptor
+
+const file_user_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"user.proto\x12\aexample\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8b\x03\n" +
+	"\x04User\x120\n" +
+	"\x04name\x18\x01 \x01(\v2\x1c.google.protobuf.StringValueR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x15\n" +
+	"\x03age\x18\x03 \x01(\x05H\x00R\x03age\x88\x01\x01\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\x03R\x02id\x126\n" +
+	"\aaliases\x18\x05 \x03(\v2\x1c.google.protobuf.StringValueR\aaliases\x12\x1b\n" +
+	"\x06avatar\x18\x06 \x01(\fH\x01R\x06avatar\x88\x01\x01\x12=\n" +
+	"\bnickname\x18\a \x01(\v2\x1c.google.protobuf.StringValueH\x02R\bnickname\x88\x01\x01\x1a;\n" +
+	"\aAddress\x120\n" +
+	"\x04city\x18\x01 \x01(\v2\x1c.google.protobuf.StringValueR\x04cityB\x06\n" +
+	"\x04_ageB\t\n" +
+	"\a_avatarB\v\n" +
+	"\t_nicknameB\x1dZ\x1bexample.com/example;exampleb\x06proto3"
+
+var (
+	file_user_proto_rawDescOnce sync.Once
+	file_user_proto_rawDescData []byte
+)
+
+func file_user_proto_rawDescGZIP() []byte {
+	file_user_proto_rawDescOnce.Do(func() {
+		file_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)))
+	})
+	return file_user_proto_rawDescData
+}
+
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_user_proto_goTypes = []any{
+	(*User)(nil),                   // 0: example.User
+	(*User_Address)(nil),           // 1: example.User.Address
+	(*wrapperspb.StringValue)(nil), // 2: google.protobuf.StringValue
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_user_proto_depIdxs = []int32{
+	2, // 0: example.User.name:type_name -> google.protobuf.StringValue
+	3, // 1: example.User.created_at:type_name -> google.protobuf.Timestamp
+	2, // 2: example.User.aliases:type_name -> google.protobuf.StringValue
+	2, // 3: example.User.nickname:type_name -> google.protobuf.StringValue
+	2, // 4: example.User.Address.city:type_name -> google.protobuf.StringValue
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_user_proto_init() }
+func file_user_proto_init() {
+	if File_user_proto != nil {
+		return
+	}
+	file_user_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_user_proto_goTypes,
+		DependencyIndexes: file_user_proto_depIdxs,
+		MessageInfos:      file_user_proto_msgTypes,
+	}.Build()
+	File_user_proto = out.File
+	file_user_proto_goTypes = nil
+	file_user_proto_depIdxs = nil
+}
@@ -0,0 +1,321 @@
+// Package csvx reads and writes structs with nilt fields as CSV records, one field per column.
+//
+// Invalid values are written as the null token, which is configurable, e.g. empty cell, NULL or \N.
+// Valid values are written using MarshalText, and read back using UnmarshalText,
+// so conversion errors are the same as reported by Scan.
+// Besides nilt types, fields can be of any encoding.TextMarshaler type, string, bool, integer or float.
+// Fields tagged with `csv:"-"` are skipped, `csv:"name"` tag overrides the column name.
+package csvx
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ParseError is returned by Reader if a cell cannot be converted into the value of a field.
+type ParseError struct {
+	Row    int    // line on which the cell starts, 1-based
+	Column int    // column of the cell, 1-based
+	Field  string // column name
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("csvx: row %d, column %d (%s): %s", e.Row, e.Column, e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Writer writes structs as CSV records.
+type Writer struct {
+	// Null is written in place of invalid values and nil pointers, it is an empty cell by default.
+	Null string
+
+	w *csv.Writer
+}
+
+// NewWriter returns Writer that writes records to w.
+func NewWriter(w *csv.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteHeader writes names of the columns of given struct.
+func (w *Writer) WriteHeader(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+
+	fields := fieldsOf(rv.Type())
+	record := make([]string, 0, len(fields))
+	for _, f := range fields {
+		record = append(record, f.name)
+	}
+
+	return w.w.Write(record)
+}
+
+// Write writes given struct, or pointer to struct, as a single record.
+func (w *Writer) Write(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+
+	fields := fieldsOf(rv.Type())
+	record := make([]string, 0, len(fields))
+	for _, f := range fields {
+		cell, err := w.format(rv.FieldByIndex(f.index))
+		if err != nil {
+			return fmt.Errorf("csvx: column %s: %w", f.name, err)
+		}
+		record = append(record, cell)
+	}
+
+	return w.w.Write(record)
+}
+
+// Flush writes any buffered data to the underlying writer and reports an error, if any occurred.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *Writer) format(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return w.Null, nil
+		}
+		v = v.Elem()
+	}
+
+	// Appear has a pointer receiver, a copy makes it callable on values that are not addressable.
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	if n, ok := p.Interface().(nullable); ok && !n.Appear() {
+		return w.Null, nil
+	}
+	if m, ok := p.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// Reader reads CSV records into structs.
+type Reader struct {
+	// Null is read as invalid value or nil pointer, it is an empty cell by default.
+	// If it is not empty, empty cell is read as valid empty string or byte slice, and is an error for other types.
+	Null string
+
+	r *csv.Reader
+	// columns maps columns to fields of typ, -1 marks a column that is skipped.
+	// It is nil until ReadHeader is called, then columns map to fields by position.
+	columns []int
+	typ     reflect.Type
+}
+
+// NewReader returns Reader that reads records from r.
+func NewReader(r *csv.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// ReadHeader reads a record with names of the columns and maps them to the fields of given struct,
+// so following calls to Read accept columns in any order. Columns that do not match any field are skipped.
+func (r *Reader) ReadHeader(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+
+	fields := fieldsOf(rv.Type())
+	columns := make([]int, len(record))
+	for i, name := range record {
+		columns[i] = -1
+		for j, f := range fields {
+			if f.name == name {
+				columns[i] = j
+				break
+			}
+		}
+	}
+
+	r.columns, r.typ = columns, rv.Type()
+	return nil
+}
+
+// Read reads the next record into given pointer to struct.
+// It returns io.EOF if there are no more records, and *ParseError if a cell cannot be converted.
+func (r *Reader) Read(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+	if !rv.CanSet() {
+		return fmt.Errorf("csvx: Read requires a pointer to struct, got %T", v)
+	}
+
+	fields := fieldsOf(rv.Type())
+	columns := r.columns
+	if columns == nil {
+		columns = make([]int, len(fields))
+		for i := range columns {
+			columns[i] = i
+		}
+	} else if r.typ != rv.Type() {
+		return fmt.Errorf("csvx: header was read for %s, got %s", r.typ, rv.Type())
+	}
+
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+	if len(record) != len(columns) {
+		row, _ := r.r.FieldPos(0)
+		return fmt.Errorf("csvx: row %d: expected %d fields, got %d", row, len(columns), len(record))
+	}
+
+	for i, cell := range record {
+		if columns[i] < 0 {
+			continue
+		}
+
+		f := fields[columns[i]]
+		if err := r.parse(cell, rv.FieldByIndex(f.index)); err != nil {
+			row, _ := r.r.FieldPos(i)
+			return &ParseError{Row: row, Column: i + 1, Field: f.name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reader) parse(cell string, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if cell == r.Null {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if n, ok := v.Addr().Interface().(nullable); ok {
+		switch cell {
+		case r.Null:
+			return n.Scan(nil)
+		case "":
+			return n.Scan(cell)
+		}
+		return n.UnmarshalText([]byte(cell))
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(cell))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		v.SetBool(b)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		v.SetInt(i)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		v.SetUint(u)
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		v.SetFloat(f)
+		return err
+	}
+
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// nullable is implemented by pointers to nilt types.
+type nullable interface {
+	sql.Scanner
+	encoding.TextUnmarshaler
+	Appear() bool
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+var fieldsCache sync.Map // map[reflect.Type][]field
+
+// fieldsOf returns exported fields of given struct type, that are not tagged with `csv:"-"`.
+func fieldsOf(typ reflect.Type) []field {
+	if fields, ok := fieldsCache.Load(typ); ok {
+		return fields.([]field)
+	}
+
+	var fields []field
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Tag.Get("csv")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields = append(fields, field{name: name, index: f.Index})
+	}
+
+	fieldsCache.Store(typ, fields)
+	return fields
+}
+
+// structOf returns the struct given directly or by a pointer.
+func structOf(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("csvx: expected struct or pointer to struct, got %T", v)
+	}
+
+	return rv, nil
+}
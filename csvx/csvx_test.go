@@ -0,0 +1,186 @@
+package csvx_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/csvx"
+)
+
+type record struct {
+	ID      int64
+	Name    nilt.String `csv:"name"`
+	Age     *nilt.Uint8
+	Score   nilt.Float64
+	Created nilt.Time
+	Timeout nilt.Duration
+	Payload nilt.Bytes
+	Limit   nilt.Of[int64]
+	Secret  string `csv:"-"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	given := []record{
+		{
+			ID:      1,
+			Name:    nilt.String{String: "john, \"jr\"", Valid: true},
+			Age:     &nilt.Uint8{Uint8: 30, Valid: true},
+			Score:   nilt.Float64{Float64: 1.5, Valid: true},
+			Created: nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 0, time.UTC), Valid: true},
+			Timeout: nilt.Duration{Duration: time.Minute, Valid: true},
+			Payload: nilt.Bytes{Bytes: []byte{0, 255}, Valid: true},
+			Limit:   nilt.Of[int64]{V: -1, Valid: true},
+		},
+		{
+			ID:      2,
+			Name:    nilt.String{String: "", Valid: true},
+			Payload: nilt.Bytes{Bytes: []byte{}, Valid: true},
+		},
+	}
+
+	for _, null := range []string{"NULL", `\N`} {
+		var buf bytes.Buffer
+		w := csvx.NewWriter(csv.NewWriter(&buf))
+		w.Null = null
+		if err := w.WriteHeader(record{}); err != nil {
+			t.Fatalf("%s: unexpected error: %s", null, err.Error())
+		}
+		for _, g := range given {
+			if err := w.Write(g); err != nil {
+				t.Fatalf("%s: unexpected error: %s", null, err.Error())
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("%s: unexpected error: %s", null, err.Error())
+		}
+
+		r := csvx.NewReader(csv.NewReader(&buf))
+		r.Null = null
+		if err := r.ReadHeader(&record{}); err != nil {
+			t.Fatalf("%s: unexpected error: %s", null, err.Error())
+		}
+		for i, g := range given {
+			var got record
+			if err := r.Read(&got); err != nil {
+				t.Fatalf("%s: unexpected error: %s", null, err.Error())
+			}
+			if !reflect.DeepEqual(got, g) {
+				t.Errorf("%s: wrong output for record %d, expected %#v but got %#v", null, i, g, got)
+			}
+		}
+		if err := r.Read(&record{}); err != io.EOF {
+			t.Errorf("%s: expected EOF, got %v", null, err)
+		}
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	cases := map[string]struct {
+		null     string
+		expected string
+	}{
+		"empty":    {null: "", expected: "1,,,,,,,\n"},
+		"NULL":     {null: "NULL", expected: "1,NULL,NULL,NULL,NULL,NULL,NULL,NULL\n"},
+		"postgres": {null: `\N`, expected: "1,\\N,\\N,\\N,\\N,\\N,\\N,\\N\n"},
+	}
+
+	for d, c := range cases {
+		var buf bytes.Buffer
+		w := csvx.NewWriter(csv.NewWriter(&buf))
+		w.Null = c.null
+		if err := w.Write(&record{ID: 1, Secret: "secret"}); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if err := w.Flush(); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if buf.String() != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", d, c.expected, buf.String())
+		}
+	}
+}
+
+func TestReader_ReadHeader(t *testing.T) {
+	given := "Score,unknown,name\n1.5,x,john\n"
+
+	r := csvx.NewReader(csv.NewReader(strings.NewReader(given)))
+	var got record
+	if err := r.ReadHeader(&got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := r.Read(&got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := record{
+		Name:  nilt.String{String: "john", Valid: true},
+		Score: nilt.Float64{Float64: 1.5, Valid: true},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("wrong output, expected %#v but got %#v", expected, got)
+	}
+}
+
+func TestReader_Read_parseError(t *testing.T) {
+	cases := map[string]struct {
+		given  string
+		row    int
+		column int
+		field  string
+	}{
+		"out of range": {given: "1,john,256,NULL,NULL,NULL,NULL,NULL\n", row: 1, column: 3, field: "Age"},
+		"empty int":    {given: "1,,NULL,NULL,NULL,NULL,,NULL\n2,,NULL,NULL,NULL,NULL,,\n", row: 2, column: 8, field: "Limit"},
+		"time":         {given: "1,john,NULL,NULL,yesterday,NULL,NULL,NULL\n", row: 1, column: 5, field: "Created"},
+		"base64":       {given: "1,\"multi\nline\",NULL,NULL,NULL,NULL,!,NULL\n", row: 2, column: 7, field: "Payload"},
+		"plain":        {given: "x,,,,,,,\n", row: 1, column: 1, field: "ID"},
+	}
+
+	for d, c := range cases {
+		r := csvx.NewReader(csv.NewReader(strings.NewReader(c.given)))
+		r.Null = "NULL"
+
+		var err error
+		for err == nil {
+			err = r.Read(&record{})
+		}
+
+		var perr *csvx.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: expected parse error, got %v", d, err)
+			continue
+		}
+		if perr.Row != c.row || perr.Column != c.column || perr.Field != c.field {
+			t.Errorf("%s: wrong position, expected %d:%d (%s) but got %d:%d (%s)", d, c.row, c.column, c.field, perr.Row, perr.Column, perr.Field)
+		}
+	}
+}
+
+func TestReader_Read_null(t *testing.T) {
+	given := "1,NULL,NULL,,,,,\n"
+
+	r := csvx.NewReader(csv.NewReader(strings.NewReader(given)))
+	r.Null = "NULL"
+	got := record{Age: &nilt.Uint8{Uint8: 1, Valid: true}}
+	err := r.Read(&got)
+
+	var perr *csvx.ParseError
+	if !errors.As(err, &perr) || perr.Field != "Score" {
+		t.Fatalf("expected parse error of Score, got %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected syntax error, got %v", err)
+	}
+	if got.Name.Valid || got.Age != nil {
+		t.Errorf("expected invalid name and nil age, got %#v", got)
+	}
+}
@@ -0,0 +1,250 @@
+package nilt
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DurationFormat defines how Duration is represented in JSON.
+type DurationFormat int
+
+const (
+	// DurationFormatString encodes duration as a string produced by time.Duration.String, e.g. "1h30m0s".
+	DurationFormatString DurationFormat = iota
+	// DurationFormatNanoseconds encodes duration as a number of nanoseconds.
+	DurationFormatNanoseconds
+)
+
+// DurationJSONFormat selects representation used by MarshalJSON of Duration.
+// UnmarshalJSON accepts both representations regardless of it.
+var DurationJSONFormat = DurationFormatString
+
+// Duration represents a time.Duration that may be nil.
+// On the wire it is compatible with the Duration message from nilt.proto,
+// which wraps google.protobuf.Duration.
+type Duration struct {
+	Duration time.Duration `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	Valid    bool          `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (d *Duration) Reset() { *d = Duration{} }
+
+// String implements proto.Message interface.
+func (d *Duration) String() string { return compactText(d.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Duration) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (d *Duration) ProtoReflect() protoreflect.Message { return durationType.message(d) }
+
+func (d *Duration) ref() ref[time.Duration] {
+	if d == nil {
+		return ref[time.Duration]{}
+	}
+	return ref[time.Duration]{v: &d.Duration, valid: &d.Valid}
+}
+
+func (d *Duration) unwrap() (interface{}, bool) {
+	return d.ref().unwrap()
+}
+
+// DurationOr returns given time.Duration value if receiver is nil or invalid.
+func (d *Duration) DurationOr(or time.Duration) time.Duration {
+	return d.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+// Valid duration is passed to the driver as int64 number of nanoseconds.
+func (d Duration) Value() (driver.Value, error) {
+	return d.ref().value()
+}
+
+// Scan implements the Scanner interface.
+// It accepts int64 number of nanoseconds, and string or []byte
+// holding number of nanoseconds, Go duration (e.g. "1h30m") or PostgreSQL interval (e.g. "1 day 02:00:00").
+func (d *Duration) Scan(value interface{}) error {
+	return d.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Representation of valid duration depends on DurationJSONFormat.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return d.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return d.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (d Duration) AppendJSON(dst []byte) ([]byte, error) {
+	return d.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (d Duration) IsZero() bool {
+	return d.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (d Duration) MarshalText() ([]byte, error) {
+	return d.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (d Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return d.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return d.ref().unmarshalXML(dec, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return d.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return d.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (d Duration) AppendBinary(b []byte) ([]byte, error) {
+	return d.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	return d.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (d Duration) GobEncode() ([]byte, error) {
+	return d.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (d *Duration) GobDecode(data []byte) error {
+	return d.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (d *Duration) Appear() bool {
+	return d.ref().appear()
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if d, err := parseInterval(s); err == nil {
+		return d, nil
+	}
+
+	return 0, fmt.Errorf("nilt: invalid duration %q", s)
+}
+
+// Lengths of calendar units, the same as PostgreSQL uses to extract epoch from an interval.
+const (
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365*day + 6*time.Hour
+)
+
+var errInvalidInterval = errors.New("nilt: invalid interval")
+
+// parseInterval parses interval in the default (postgres) output style,
+// e.g. "1 year 2 mons -3 days +04:05:06.789".
+func parseInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, errInvalidInterval
+	}
+
+	var d time.Duration
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			t, err := parseIntervalTime(fields[i])
+			if err != nil {
+				return 0, err
+			}
+			d += t
+			continue
+		}
+		if i+1 == len(fields) {
+			return 0, errInvalidInterval
+		}
+
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return 0, errInvalidInterval
+		}
+		i++
+		switch fields[i] {
+		case "year", "years":
+			d += time.Duration(n) * year
+		case "mon", "mons":
+			d += time.Duration(n) * month
+		case "day", "days":
+			d += time.Duration(n) * day
+		default:
+			return 0, errInvalidInterval
+		}
+	}
+
+	return d, nil
+}
+
+// parseIntervalTime parses time part of an interval, e.g. "-04:05:06.789".
+func parseIntervalTime(s string) (time.Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, errInvalidInterval
+	}
+	d, err := time.ParseDuration(parts[0] + "h" + parts[1] + "m" + parts[2] + "s")
+	if err != nil {
+		return 0, errInvalidInterval
+	}
+
+	if neg {
+		return -d, nil
+	}
+	return d, nil
+}
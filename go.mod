@@ -0,0 +1,7 @@
+module github.com/piotrkowalczuk/nilt
+
+go 1.24
+
+require google.golang.org/protobuf v1.36.9
+
+require github.com/google/go-cmp v0.6.0 // indirect
@@ -0,0 +1,25 @@
+package nilt
+
+import "fmt"
+
+// gobVersion is the version of the layout produced by GobEncode.
+// It is written as the first byte, followed by the validity byte and the payload,
+// so the layout does not depend on names and order of the struct fields.
+const gobVersion byte = 1
+
+func (r ref[T]) gobEncode() ([]byte, error) {
+	return r.appendBinary([]byte{gobVersion})
+}
+
+func (r ref[T]) gobDecode(data []byte) error {
+	if len(data) == 0 {
+		r.reset()
+		return errMalformedBinary
+	}
+	if data[0] != gobVersion {
+		r.reset()
+		return fmt.Errorf("nilt: unsupported gob version %d", data[0])
+	}
+
+	return r.unmarshalBinary(data[1:])
+}
@@ -0,0 +1,336 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package nilt
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// This file implements encoding/json/v2 interfaces, which are available if the jsonv2 experiment is enabled (GOEXPERIMENT=jsonv2).
+// The go1.27 constraint is required by vet, which reports use of encoding/json/v2 symbols in files of older Go versions,
+// as the module declares go 1.24.
+// The methods write and read tokens directly, without intermediate allocations for the most of the types,
+// respect StringifyNumbers option (`string` tag option) and, like their v1 counterparts, JSONObject.
+// Fields tagged with omitzero option are omitted if invalid, because of IsZero method.
+
+func (r ref[T]) marshalJSONTo(enc *jsontext.Encoder) error {
+	if !JSONObject {
+		return r.marshalJSONToScalar(enc)
+	}
+
+	if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+		return err
+	}
+	if r.ok() {
+		if !isEmpty(*r.v) {
+			if err := enc.WriteToken(jsontext.String("value")); err != nil {
+				return err
+			}
+			if err := r.marshalJSONToScalar(enc); err != nil {
+				return err
+			}
+		}
+		if err := enc.WriteToken(jsontext.String("valid")); err != nil {
+			return err
+		}
+		if err := enc.WriteToken(jsontext.True); err != nil {
+			return err
+		}
+	}
+
+	return enc.WriteToken(jsontext.EndObject)
+}
+
+func (r ref[T]) marshalJSONToScalar(enc *jsontext.Encoder) error {
+	if !r.ok() {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	var buf [64]byte
+	switch v := any(*r.v).(type) {
+	case string:
+		return enc.WriteToken(jsontext.String(v))
+	case bool:
+		return enc.WriteToken(jsontext.Bool(v))
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return fmt.Errorf("nilt: unsupported value: %v", v)
+		}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("nilt: unsupported value: %v", v)
+		}
+	case time.Duration:
+		if DurationJSONFormat == DurationFormatString {
+			return r.writeQuotedText(enc, buf[:0])
+		}
+	case time.Time, []byte:
+		return r.writeQuotedText(enc, buf[:0])
+	}
+
+	stringify, _ := json.GetOption(enc.Options(), json.StringifyNumbers)
+	if _, ok := any(*r.v).(uint64); ok && Uint64JSONString {
+		stringify = true
+	}
+
+	b := buf[:0]
+	if stringify {
+		b = append(b, '"')
+	}
+	if d, ok := any(*r.v).(time.Duration); ok {
+		b = strconv.AppendInt(b, int64(d), 10)
+	} else {
+		b, _ = r.appendText(b) // cannot fail for numbers
+	}
+	if stringify {
+		b = append(b, '"')
+	}
+
+	return enc.WriteValue(b)
+}
+
+func (r ref[T]) writeQuotedText(enc *jsontext.Encoder, buf []byte) error {
+	b, err := r.appendQuotedText(buf)
+	if err != nil {
+		return err
+	}
+
+	return enc.WriteValue(b)
+}
+
+func (r ref[T]) unmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	switch val.Kind() {
+	case 'n':
+		r.reset()
+		return nil
+	case '{':
+		return r.unmarshalJSONObject(val)
+	case '0':
+		if isNumber[T]() {
+			return r.scan([]byte(val))
+		}
+	case '"':
+		if stringify, _ := json.GetOption(dec.Options(), json.StringifyNumbers); stringify && isNumber[T]() {
+			var buf [64]byte
+			b, err := jsontext.AppendUnquote(buf[:0], val)
+			if err != nil {
+				r.reset()
+				return err
+			}
+			return r.scan(b)
+		}
+	}
+
+	return r.unmarshalJSON(val)
+}
+
+// isNumber reports whether T is represented as a JSON number.
+func isNumber[T Scalar]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Duration:
+		return true
+	}
+
+	return false
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (s String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return s.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (s *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return s.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (i Int64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return i.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (i *Int64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return i.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (i Int8) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return i.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (i *Int8) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return i.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (i Int16) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return i.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (i *Int16) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return i.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (i Int32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return i.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (i *Int32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return i.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (i Int) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return i.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (i *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return i.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (u Uint32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return u.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (u *Uint32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return u.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (u Uint) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return u.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (u *Uint) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return u.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (u Uint8) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return u.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (u *Uint8) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return u.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (u Uint16) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return u.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (u *Uint16) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return u.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (u Uint64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return u.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (u *Uint64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return u.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (f Float32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return f.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (f *Float32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return f.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (f Float64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return f.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (f *Float64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return f.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (b Bool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return b.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (b *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return b.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return t.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return t.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (d Duration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return d.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (d *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return d.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (b Bytes) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return b.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (b *Bytes) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return b.ref().unmarshalJSONFrom(dec)
+}
+
+// MarshalJSONTo implements json.MarshalerTo interface.
+func (o Of[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return o.ref().marshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (o *Of[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return o.ref().unmarshalJSONFrom(dec)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom interface.
+func (l *Lenient[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	return l.ref().unmarshalJSONLenient(val)
+}
@@ -0,0 +1,165 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package nilt_test
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+)
+
+func TestJSONv2_symmetry(t *testing.T) {
+	for _, object := range []bool{false, true} {
+		nilt.JSONObject = object
+
+		for _, e := range examples {
+			b, err := json.Marshal(e)
+			if err != nil {
+				t.Errorf("%T: unexpected error: %s", e, err.Error())
+				continue
+			}
+			v1, err := e.MarshalJSON()
+			if err != nil {
+				t.Errorf("%T: unexpected error: %s", e, err.Error())
+				continue
+			}
+			if string(b) != string(v1) {
+				t.Errorf("%T: output differs from v1, expected %s but got %s", e, v1, b)
+			}
+
+			got := newLike(e)
+			if err = json.Unmarshal(b, got); err != nil {
+				t.Errorf("%T: unexpected error for %s: %s", e, b, err.Error())
+				continue
+			}
+
+			if !e.Appear() {
+				if !reflect.DeepEqual(got, newLike(e)) {
+					t.Errorf("%T: expected zero value after unmarshaling %s, got %#v", e, b, got)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(got, e) {
+				t.Errorf("%T: wrong output for %s, expected %#v but got %#v", e, b, e, got)
+			}
+		}
+	}
+	nilt.JSONObject = false
+}
+
+func TestJSONv2_within(t *testing.T) {
+	type within struct {
+		ID      nilt.Int64    `json:"id,string"`
+		Name    nilt.String   `json:"name,omitzero"`
+		Score   nilt.Float32  `json:"score,omitzero,string"`
+		Timeout nilt.Duration `json:"timeout"`
+		Big     nilt.Uint64   `json:"big"`
+	}
+
+	given := within{
+		ID:      nilt.Int64{Int64: 1, Valid: true},
+		Score:   nilt.Float32{Float32: 1.1, Valid: true},
+		Timeout: nilt.Duration{Duration: time.Second, Valid: true},
+	}
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := `{"id":"1","score":"1.1","timeout":"1s","big":null}`; string(b) != expected {
+		t.Errorf("wrong output, expected %s but got %s", expected, string(b))
+	}
+
+	var got within
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("wrong output, expected %#v but got %#v", given, got)
+	}
+
+	if err = json.Unmarshal([]byte(`{"id":"x"}`), &got); err == nil {
+		t.Error("expected error")
+	}
+	if got.ID.Valid {
+		t.Errorf("expected invalid id after failed unmarshal, got %#v", got.ID)
+	}
+}
+
+func TestJSONv2_StringifyNumbers(t *testing.T) {
+	given := []nilt.Of[int8]{{V: -1, Valid: true}, {}}
+
+	b, err := json.Marshal(given, json.StringifyNumbers(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := `["-1",null]`; string(b) != expected {
+		t.Errorf("wrong output, expected %s but got %s", expected, string(b))
+	}
+
+	var got []nilt.Of[int8]
+	if err = json.Unmarshal(b, &got, json.StringifyNumbers(true)); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("wrong output, expected %#v but got %#v", given, got)
+	}
+
+	if err = json.Unmarshal([]byte(`["128"]`), &got, json.StringifyNumbers(true)); err == nil {
+		t.Error("expected error for out of range value")
+	}
+}
+
+func TestJSONv2_invalidInput(t *testing.T) {
+	cases := map[string]nullable{
+		`"text"`: &nilt.Int64{Int64: 1, Valid: true},
+		`1`:      &nilt.String{String: "text", Valid: true},
+		`1.5`:    &nilt.Int32{Int32: 1, Valid: true},
+		`-1`:     &nilt.Uint8{Uint8: 1, Valid: true},
+		`"1d"`:   &nilt.Duration{Duration: 1, Valid: true},
+		`true`:   &nilt.Float64{Float64: 1, Valid: true},
+		`"1"`:    &nilt.Int8{Int8: 1, Valid: true},
+	}
+
+	for given, n := range cases {
+		if err := json.Unmarshal([]byte(given), n); err == nil {
+			t.Errorf("%T: expected error for %s", n, given)
+		}
+		if n.Appear() {
+			t.Errorf("%T: expected to be invalid after failed unmarshal of %s", n, given)
+		}
+	}
+}
+
+func BenchmarkJSONv2_MarshalEncode(b *testing.B) {
+	enc := jsontext.NewEncoder(discard{})
+	v := nilt.Float64{Float64: 1.5, Valid: true}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := json.MarshalEncode(enc, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestJSONv2_Lenient(t *testing.T) {
+	var got struct {
+		ID   nilt.Lenient[int64]  `json:"id"`
+		Name nilt.Lenient[string] `json:"name"`
+		Age  nilt.Lenient[uint8]  `json:"age"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":"42","name":42,"age":""}`), &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got.ID.V != 42 || !got.ID.Valid || got.Name.V != "42" || !got.Name.Valid || got.Age.Valid {
+		t.Errorf("wrong output, got %#v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package nilt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Lenient represents a value of type T that may be nil, the same as Of,
+// but its UnmarshalJSON accepts representations used by some third-party APIs, which Of rejects:
+// numbers and booleans encoded as strings, e.g. "42" or "true", numbers where string is expected, e.g. 42,
+// and empty string as null, except for string and []byte, for which it is a valid empty value.
+// It is encoded the same as Of.
+type Lenient[T Scalar] struct {
+	Of[T]
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (l *Lenient[T]) UnmarshalJSON(data []byte) error {
+	return l.ref().unmarshalJSONLenient(data)
+}
+
+func (r ref[T]) unmarshalJSONLenient(data []byte) error {
+	if len(data) == 0 {
+		return r.unmarshalJSON(data)
+	}
+
+	switch c := data[0]; {
+	case c == '"':
+		switch any(*r.v).(type) {
+		case string, []byte:
+			return r.unmarshalJSON(data)
+		}
+
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			r.reset()
+			return err
+		}
+		if s == "" {
+			r.reset()
+			return nil
+		}
+
+		if _, ok := any(*r.v).(time.Time); ok {
+			return r.unmarshalJSON(data)
+		}
+		if err := r.scan(s); err != nil {
+			return err
+		}
+		return r.finite()
+	case c == '-' || (c >= '0' && c <= '9'):
+		if _, ok := any(*r.v).(string); ok {
+			return r.scan(string(data))
+		}
+	}
+
+	return r.unmarshalJSON(data)
+}
+
+// finite resets the value and returns an error if it is NaN or infinity,
+// which strconv accepts, but MarshalJSON cannot encode.
+func (r ref[T]) finite() error {
+	var f float64
+	switch v := any(*r.v).(type) {
+	case float32:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return nil
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		r.reset()
+		return fmt.Errorf("nilt: unsupported value: %v", f)
+	}
+	return nil
+}
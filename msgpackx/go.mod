@@ -0,0 +1,17 @@
+module github.com/piotrkowalczuk/nilt/msgpackx
+
+go 1.24
+
+require (
+	github.com/piotrkowalczuk/nilt v0.0.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	google.golang.org/protobuf v1.36.9 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/piotrkowalczuk/nilt => ../
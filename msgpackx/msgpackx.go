@@ -0,0 +1,143 @@
+// Package msgpackx provides MessagePack support for nilt types,
+// compatible with github.com/vmihailenco/msgpack/v5.
+// It is a separate package, so that the msgpack module is required only by programs that use it.
+//
+// Invalid value is encoded as nil and valid value as the native scalar of its type.
+// Decoding accepts any integer or float width, as long as decoded value fits into the destination.
+package msgpackx
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// Register registers encoder and decoder of every nilt type, except generic Of, in msgpack package.
+// It has to be called before the first use of msgpack with nilt types, e.g. in an init function.
+func Register() {
+	for _, v := range []interface{}{
+		nilt.String{},
+		nilt.Int{}, nilt.Int8{}, nilt.Int16{}, nilt.Int32{}, nilt.Int64{},
+		nilt.Uint{}, nilt.Uint8{}, nilt.Uint16{}, nilt.Uint32{}, nilt.Uint64{},
+		nilt.Float32{}, nilt.Float64{},
+		nilt.Bool{},
+		nilt.Time{}, nilt.Duration{}, nilt.Bytes{},
+	} {
+		msgpack.Register(v, encode, decode)
+	}
+}
+
+// RegisterOf registers encoder and decoder of nilt.Of[T] in msgpack package.
+func RegisterOf[T nilt.Scalar]() {
+	msgpack.Register(nilt.Of[T]{}, encode, decode)
+}
+
+// encode writes v, one of nilt types, as MessagePack nil if it is invalid,
+// otherwise as the native MessagePack scalar of its value, keeping the width of floats.
+func encode(e *msgpack.Encoder, v reflect.Value) error {
+	if !v.CanAddr() {
+		// Unwrap needs a pointer, so a copy of the value is unwrapped.
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	value, valid, ok := nilt.Unwrap(v.Addr().Interface())
+	if !ok {
+		return fmt.Errorf("msgpackx: unsupported type %s", v.Type())
+	}
+	if !valid {
+		return e.EncodeNil()
+	}
+
+	switch f := reflect.ValueOf(value); f.Kind() {
+	case reflect.String:
+		return e.EncodeString(f.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.EncodeInt(f.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return e.EncodeUint(f.Uint())
+	case reflect.Float32:
+		return e.EncodeFloat32(float32(f.Float()))
+	case reflect.Float64:
+		return e.EncodeFloat64(f.Float())
+	case reflect.Bool:
+		return e.EncodeBool(f.Bool())
+	case reflect.Slice:
+		return e.EncodeBytes(f.Bytes())
+	}
+	if t, ok := value.(time.Time); ok {
+		return e.EncodeTime(t)
+	}
+
+	return fmt.Errorf("msgpackx: unsupported type %s", v.Type())
+}
+
+// decode reads MessagePack nil or a scalar matching the value type of v and passes it to Scan method of v,
+// which validates the range of decoded value.
+func decode(d *msgpack.Decoder, v reflect.Value) error {
+	p := v.Addr().Interface()
+	value, _, ok := nilt.Unwrap(p)
+	if !ok {
+		return fmt.Errorf("msgpackx: unsupported type %s", v.Type())
+	}
+	s := p.(sql.Scanner)
+
+	c, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+	if c == msgpcode.Nil {
+		if err = d.DecodeNil(); err != nil {
+			return err
+		}
+		return s.Scan(nil)
+	}
+
+	var decoded interface{}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		decoded, err = d.DecodeString()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		decoded, err = decodeInteger(d, c)
+	case reflect.Float32, reflect.Float64:
+		decoded, err = d.DecodeFloat64()
+	case reflect.Bool:
+		decoded, err = d.DecodeBool()
+	case reflect.Slice:
+		decoded, err = d.DecodeBytes()
+	default:
+		if _, ok := value.(time.Time); !ok {
+			return fmt.Errorf("msgpackx: unsupported type %s", v.Type())
+		}
+		decoded, err = d.DecodeTime()
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.Scan(decoded)
+}
+
+// decodeInteger decodes integer of any width.
+// It returns int64, unless the value is too big for it, then uint64 is returned.
+func decodeInteger(d *msgpack.Decoder, c byte) (interface{}, error) {
+	switch {
+	case msgpcode.IsFixedNum(c), c == msgpcode.Int8, c == msgpcode.Int16, c == msgpcode.Int32, c == msgpcode.Int64:
+		return d.DecodeInt64()
+	case c == msgpcode.Uint8, c == msgpcode.Uint16, c == msgpcode.Uint32, c == msgpcode.Uint64:
+		u, err := d.DecodeUint64()
+		if err != nil || u > math.MaxInt64 {
+			return u, err
+		}
+		return int64(u), nil
+	}
+
+	return nil, fmt.Errorf("msgpackx: cannot decode code %#x into integer", c)
+}
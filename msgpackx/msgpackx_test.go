@@ -0,0 +1,139 @@
+package msgpackx_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/msgpackx"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+func init() {
+	msgpackx.Register()
+	msgpackx.RegisterOf[int64]()
+}
+
+type record struct {
+	Name    nilt.String
+	Count   nilt.Int64
+	Small   nilt.Int8
+	Big     nilt.Uint64
+	Ratio   nilt.Float32
+	Score   *nilt.Float64
+	Active  nilt.Bool
+	Created nilt.Time
+	Timeout nilt.Duration
+	Payload nilt.Bytes
+	Limit   nilt.Of[int64]
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]record{
+		"valid": {
+			Name:    nilt.String{String: "text", Valid: true},
+			Count:   nilt.Int64{Int64: math.MinInt64, Valid: true},
+			Small:   nilt.Int8{Int8: -1, Valid: true},
+			Big:     nilt.Uint64{Uint64: math.MaxUint64, Valid: true},
+			Ratio:   nilt.Float32{Float32: 1.1, Valid: true},
+			Score:   &nilt.Float64{Float64: 0, Valid: true},
+			Active:  nilt.Bool{Bool: false, Valid: true},
+			Created: nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC), Valid: true},
+			Timeout: nilt.Duration{Duration: time.Minute, Valid: true},
+			Payload: nilt.Bytes{Bytes: []byte{}, Valid: true},
+			Limit:   nilt.Of[int64]{V: 5, Valid: true},
+		},
+		"invalid": {
+			Score: &nilt.Float64{},
+		},
+	}
+
+	for d, given := range cases {
+		b, err := msgpack.Marshal(given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		var got record
+		if err = msgpack.Unmarshal(b, &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		// msgpack decodes time in local time zone
+		got.Created.Time = got.Created.Time.UTC()
+		if d == "invalid" {
+			// nil is decoded into nil pointer
+			given.Score = nil
+		}
+		if !reflect.DeepEqual(got, given) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, given, got)
+		}
+	}
+}
+
+func TestEncode_nil(t *testing.T) {
+	b, err := msgpack.Marshal(nilt.Int64{Int64: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !bytes.Equal(b, []byte{msgpcode.Nil}) {
+		t.Errorf("wrong output, expected nil but got %#v", b)
+	}
+}
+
+func TestDecode_width(t *testing.T) {
+	success := map[string]struct {
+		given    interface{}
+		into     interface{}
+		expected interface{}
+	}{
+		"uint8 into int64":   {given: uint8(200), into: &nilt.Int64{}, expected: &nilt.Int64{Int64: 200, Valid: true}},
+		"int64 into int8":    {given: int64(-100), into: &nilt.Int8{}, expected: &nilt.Int8{Int8: -100, Valid: true}},
+		"int8 into uint16":   {given: int8(100), into: &nilt.Uint16{}, expected: &nilt.Uint16{Uint16: 100, Valid: true}},
+		"float64 into f32":   {given: 1.5, into: &nilt.Float32{}, expected: &nilt.Float32{Float32: 1.5, Valid: true}},
+		"float32 into f64":   {given: float32(1.5), into: &nilt.Float64{}, expected: &nilt.Float64{Float64: 1.5, Valid: true}},
+		"int into float64":   {given: 3, into: &nilt.Float64{}, expected: &nilt.Float64{Float64: 3, Valid: true}},
+		"uint64 into uint32": {given: uint64(7), into: &nilt.Uint32{}, expected: &nilt.Uint32{Uint32: 7, Valid: true}},
+	}
+
+	for d, c := range success {
+		b, err := msgpack.Marshal(c.given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", d, err.Error())
+		}
+		if err = msgpack.Unmarshal(b, c.into); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(c.into, c.expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, c.into)
+		}
+	}
+
+	failure := map[string]struct {
+		given interface{}
+		into  interface{}
+	}{
+		"int16 into int8":   {given: int16(300), into: &nilt.Int8{}},
+		"negative into u64": {given: int64(-1), into: &nilt.Uint64{}},
+		"uint64 into int64": {given: uint64(math.MaxUint64), into: &nilt.Int64{}},
+		"float into int":    {given: 1.5, into: &nilt.Int{}},
+		"string into int":   {given: "1", into: &nilt.Int{}},
+	}
+
+	for d, c := range failure {
+		b, err := msgpack.Marshal(c.given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", d, err.Error())
+		}
+		if err = msgpack.Unmarshal(b, c.into); err == nil {
+			t.Errorf("%s: expected error", d)
+		}
+	}
+}
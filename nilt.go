@@ -1,14 +1,17 @@
+// Package nilt provides types that represent values that may be nil,
+// e.g. nullable columns of a database or optional fields of a JSON object.
+//
+// Methods that encode a value, e.g. MarshalJSON, MarshalText and Value, have value receivers,
+// so that the types can be used as map keys and encoded when they are not addressable.
+// Calling such a method directly through a nil pointer panics, as for any value receiver,
+// while encoding/json checks for nil pointer itself and encodes it as null.
 package nilt
 
 import (
 	"database/sql/driver"
-	"encoding/json"
-	"errors"
-	"fmt"
+	"encoding/xml"
 
-	"strconv"
-
-	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // String represents a string that may be nil.
@@ -17,75 +20,126 @@ type String struct {
 	Valid  bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
 }
 
+// Reset implements proto.Message interface.
 func (m *String) Reset() { *m = String{} }
 
-//func (m *String) String() string { return proto.CompactTextString(m) }
+// ProtoMessage implements proto.Message interface.
+// String cannot have String method, because it would collide with String field,
+// therefore it implements only proto.Message of google.golang.org/protobuf.
 func (*String) ProtoMessage() {}
 
-// StringOr returns given string value if receiver is nil or invalid.
-func (s *String) StringOr(or string) string {
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (m *String) ProtoReflect() protoreflect.Message { return stringType.message(m) }
+
+func (s *String) ref() ref[string] {
 	if s == nil {
-		return or
-	}
-	if !s.Valid {
-		return or
+		return ref[string]{}
 	}
+	return ref[string]{v: &s.String, valid: &s.Valid}
+}
 
-	return s.String
+func (s *String) unwrap() (interface{}, bool) {
+	return s.ref().unwrap()
+}
+
+// StringOr returns given string value if receiver is nil or invalid.
+func (s *String) StringOr(or string) string {
+	return s.ref().or(or)
 }
 
 // Appear implements pqcomp Appearer interface.
 func (s *String) Appear() bool {
-	return s != nil && s.Valid
+	return s.ref().appear()
 }
 
 // MarshalJSON implements json.Marshaler interface.
-func (s *String) MarshalJSON() ([]byte, error) {
-	if s == nil || !s.Valid {
-		return []byte("null"), nil
-	}
-
-	return json.Marshal(s.String)
+func (s String) MarshalJSON() ([]byte, error) {
+	return s.ref().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (s *String) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		s.String, s.Valid = "", false
-		return nil
-	}
+	return s.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (s String) AppendJSON(dst []byte) ([]byte, error) {
+	return s.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (s String) IsZero() bool {
+	return s.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text,
+// so valid empty string is decoded back by UnmarshalText as invalid.
+func (s String) MarshalText() ([]byte, error) {
+	return s.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (s *String) UnmarshalText(text []byte) error {
+	return s.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (s String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return s.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (s *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return s.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return s.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.ref().unmarshalXMLAttr(attr)
+}
 
-	s.Valid = true
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (s String) MarshalBinary() ([]byte, error) {
+	return s.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (s String) AppendBinary(b []byte) ([]byte, error) {
+	return s.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (s *String) UnmarshalBinary(data []byte) error {
+	return s.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (s String) GobEncode() ([]byte, error) {
+	return s.ref().gobEncode()
+}
 
-	return json.Unmarshal(data, &s.String)
+// GobDecode implements gob.GobDecoder interface.
+func (s *String) GobDecode(data []byte) error {
+	return s.ref().gobDecode(data)
 }
 
 // Value implements the driver Valuer interface.
 func (s String) Value() (driver.Value, error) {
-	if !s.Valid {
-		return nil, nil
-	}
-	return s.String, nil
+	return s.ref().value()
 }
 
 // Scan implements the Scanner interface.
 func (s *String) Scan(value interface{}) error {
-	if value == nil {
-		s.String, s.Valid = "", false
-		return nil
-	}
-	s.Valid = true
-
-	switch v := value.(type) {
-	case []byte:
-		s.String = string(v)
-	case string:
-		s.String = v
-	default:
-		return fmt.Errorf("nilt: unsuported type (%T) passed to String.Scan", value)
-	}
-
-	return nil
+	return s.ref().scan(value)
 }
 
 // Int64 represents a int64 that may be nil.
@@ -98,591 +152,1659 @@ type Int64 struct {
 func (ni *Int64) Reset() { *ni = Int64{} }
 
 // String implements proto.Message interface.
-func (ni *Int64) String() string { return proto.CompactTextString(ni) }
+func (ni *Int64) String() string { return compactText(ni.ProtoReflect()) }
 
 // ProtoMessage implements proto.Message interface.
 func (*Int64) ProtoMessage() {}
 
-// Int64Or returns given int64 value if receiver is nil or invalid.
-func (i *Int64) Int64Or(or int64) int64 {
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (ni *Int64) ProtoReflect() protoreflect.Message { return int64Type.message(ni) }
+
+func (i *Int64) ref() ref[int64] {
 	if i == nil {
-		return or
-	}
-	if !i.Valid {
-		return or
+		return ref[int64]{}
 	}
+	return ref[int64]{v: &i.Int64, valid: &i.Valid}
+}
 
-	return i.Int64
+func (i *Int64) unwrap() (interface{}, bool) {
+	return i.ref().unwrap()
+}
+
+// Int64Or returns given int64 value if receiver is nil or invalid.
+func (i *Int64) Int64Or(or int64) int64 {
+	return i.ref().or(or)
 }
 
 // Value implements the driver Valuer interface.
 func (i Int64) Value() (driver.Value, error) {
-	if !i.Valid {
-		return nil, nil
-	}
-	return i.Int64, nil
+	return i.ref().value()
 }
 
 // Scan implements the Scanner interface.
-func (i *Int64) Scan(value interface{}) (err error) {
-	if value == nil {
-		i.Int64, i.Valid = 0, false
-		return nil
-	}
-	i.Valid = true
-
-	switch v := value.(type) {
-	case []byte:
-		i.Int64, err = strconv.ParseInt(string(v), 10, 64)
-	case string:
-		i.Int64, err = strconv.ParseInt(v, 10, 64)
-	case int64:
-		i.Int64 = v
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Int64.Scan", value)
-	}
-
-	return
+func (i *Int64) Scan(value interface{}) error {
+	return i.ref().scan(value)
 }
 
 // MarshalJSON implements json.Marshaler interface.
-func (i *Int64) MarshalJSON() ([]byte, error) {
-	if i == nil || !i.Valid {
-		return []byte("null"), nil
-	}
-
-	return json.Marshal(i.Int64)
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return i.ref().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (i *Int64) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		i.Int64, i.Valid = 0, false
-		return nil
-	}
-
-	i.Valid = true
-
-	return json.Unmarshal(data, &i.Int64)
+	return i.ref().unmarshalJSON(data)
 }
 
-// Appear implements pqcomp Appearer interface.
-func (i *Int64) Appear() bool {
-	return i != nil && i.Valid
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (i Int64) AppendJSON(dst []byte) ([]byte, error) {
+	return i.ref().appendJSON(dst)
 }
 
-// Int32 represents a int32 that may be nil.
-type Int32 struct {
-	Int32 int32 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
-	Valid bool  `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+// IsZero reports whether the value is invalid.
+func (i Int64) IsZero() bool {
+	return i.ref().isZero()
 }
 
-// Reset implements proto.Message interface.
-func (ni *Int32) Reset() { *ni = Int32{} }
-
-// String implements proto.Message interface.
-func (ni *Int32) String() string { return proto.CompactTextString(ni) }
-
-// ProtoMessage implements proto.Message interface.
-func (*Int32) ProtoMessage() {}
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (i Int64) MarshalText() ([]byte, error) {
+	return i.ref().marshalText()
+}
 
-// Int32Or returns given int32 value if receiver is nil or invalid.
-func (i *Int32) Int32Or(or int32) int32 {
-	if i == nil {
-		return or
-	}
-	if !i.Valid {
-		return or
-	}
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (i *Int64) UnmarshalText(text []byte) error {
+	return i.ref().unmarshalText(text)
+}
 
-	return i.Int32
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (i Int64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.ref().marshalXML(e, start)
 }
 
-// Value implements the driver Valuer interface.
-func (i Int32) Value() (driver.Value, error) {
-	if !i.Valid {
-		return nil, nil
-	}
-	return int64(i.Int32), nil
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (i *Int64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.ref().unmarshalXML(d, start)
 }
 
-// Scan implements the Scanner interface.
-func (i *Int32) Scan(value interface{}) (err error) {
-	if value == nil {
-		i.Int32, i.Valid = 0, false
-		return nil
-	}
-	i.Valid = true
-
-	var tmp int64
-	switch v := value.(type) {
-	case []byte:
-		tmp, err = strconv.ParseInt(string(v), 10, 32)
-		i.Int32 = int32(tmp)
-	case string:
-		tmp, err = strconv.ParseInt(v, 10, 32)
-		i.Int32 = int32(tmp)
-	case int64:
-		i.Int32 = int32(v)
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Int32.Scan", value)
-	}
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (i Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.ref().marshalXMLAttr(name)
+}
 
-	return
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (i *Int64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.ref().unmarshalXMLAttr(attr)
 }
 
-// MarshalJSON implements json.Marshaler interface.
-func (i *Int32) MarshalJSON() ([]byte, error) {
-	if i == nil || !i.Valid {
-		return []byte("null"), nil
-	}
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (i Int64) MarshalBinary() ([]byte, error) {
+	return i.ref().appendBinary(nil)
+}
 
-	return json.Marshal(i.Int32)
+// AppendBinary implements encoding.BinaryAppender interface.
+func (i Int64) AppendBinary(b []byte) ([]byte, error) {
+	return i.ref().appendBinary(b)
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
-func (i *Int32) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		i.Int32, i.Valid = 0, false
-		return nil
-	}
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (i *Int64) UnmarshalBinary(data []byte) error {
+	return i.ref().unmarshalBinary(data)
+}
 
-	i.Valid = true
+// GobEncode implements gob.GobEncoder interface.
+func (i Int64) GobEncode() ([]byte, error) {
+	return i.ref().gobEncode()
+}
 
-	return json.Unmarshal(data, &i.Int32)
+// GobDecode implements gob.GobDecoder interface.
+func (i *Int64) GobDecode(data []byte) error {
+	return i.ref().gobDecode(data)
 }
 
 // Appear implements pqcomp Appearer interface.
-func (i *Int32) Appear() bool {
-	return i != nil && i.Valid
+func (i *Int64) Appear() bool {
+	return i.ref().appear()
 }
 
-// Int represents a int that may be nil.
-type Int struct {
-	Int   int  `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+// Int8 represents a int8 that may be nil.
+type Int8 struct {
+	Int8  int8 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
 	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
 }
 
 // Reset implements proto.Message interface.
-func (i *Int) Reset() { *i = Int{} }
+func (i *Int8) Reset() { *i = Int8{} }
 
 // String implements proto.Message interface.
-func (i *Int) String() string { return proto.CompactTextString(i) }
+func (i *Int8) String() string { return compactText(i.ProtoReflect()) }
 
 // ProtoMessage implements proto.Message interface.
-func (*Int) ProtoMessage() {}
+func (*Int8) ProtoMessage() {}
 
-// IntOr returns given int value if receiver is nil or invalid.
-func (i *Int) IntOr(or int) int {
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (i *Int8) ProtoReflect() protoreflect.Message { return int8Type.message(i) }
+
+func (i *Int8) ref() ref[int8] {
 	if i == nil {
-		return or
-	}
-	if !i.Valid {
-		return or
+		return ref[int8]{}
 	}
+	return ref[int8]{v: &i.Int8, valid: &i.Valid}
+}
 
-	return i.Int
+func (i *Int8) unwrap() (interface{}, bool) {
+	return i.ref().unwrap()
+}
+
+// Int8Or returns given int8 value if receiver is nil or invalid.
+func (i *Int8) Int8Or(or int8) int8 {
+	return i.ref().or(or)
 }
 
 // Value implements the driver Valuer interface.
-func (i Int) Value() (driver.Value, error) {
-	if !i.Valid {
-		return nil, nil
-	}
-	return i.Int, nil
+func (i Int8) Value() (driver.Value, error) {
+	return i.ref().value()
 }
 
 // Scan implements the Scanner interface.
-func (i *Int) Scan(value interface{}) (err error) {
-	if value == nil {
-		i.Int, i.Valid = 0, false
-		return nil
-	}
-	i.Valid = true
-
-	var tmp int64
-	switch v := value.(type) {
-	case []byte:
-		tmp, err = strconv.ParseInt(string(v), 10, 32)
-		i.Int = int(tmp)
-	case string:
-		tmp, err = strconv.ParseInt(v, 10, 32)
-		i.Int = int(tmp)
-	case int64:
-		i.Int = int(v)
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Int.Scan", value)
-	}
-
-	return
+func (i *Int8) Scan(value interface{}) error {
+	return i.ref().scan(value)
 }
 
 // MarshalJSON implements json.Marshaler interface.
-func (i *Int) MarshalJSON() ([]byte, error) {
-	if i == nil || !i.Valid {
-		return []byte("null"), nil
-	}
-
-	return json.Marshal(i.Int)
+func (i Int8) MarshalJSON() ([]byte, error) {
+	return i.ref().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
-func (i *Int) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		i.Int, i.Valid = 0, false
-		return nil
-	}
-
-	i.Valid = true
-
-	return json.Unmarshal(data, &i.Int)
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	return i.ref().unmarshalJSON(data)
 }
 
-// Appear implements pqcomp Appearer interface.
-func (i *Int) Appear() bool {
-	return i != nil && i.Valid
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (i Int8) AppendJSON(dst []byte) ([]byte, error) {
+	return i.ref().appendJSON(dst)
 }
 
-// Uint32 represents a uint32 that may be nil.
-type Uint32 struct {
-	Uint32 uint32 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
-	Valid  bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+// IsZero reports whether the value is invalid.
+func (i Int8) IsZero() bool {
+	return i.ref().isZero()
 }
 
-// Reset implements proto.Message interface.
-func (u *Uint32) Reset() { *u = Uint32{} }
-
-// String implements proto.Message interface.
-func (u *Uint32) String() string { return proto.CompactTextString(u) }
-
-// ProtoMessage implements proto.Message interface.
-func (*Uint32) ProtoMessage() {}
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (i Int8) MarshalText() ([]byte, error) {
+	return i.ref().marshalText()
+}
 
-// Uint32Or returns given uint32 value if receiver is nil or invalid.
-func (u *Uint32) Uint32Or(or uint32) uint32 {
-	if u == nil {
-		return or
-	}
-	if !u.Valid {
-		return or
-	}
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (i *Int8) UnmarshalText(text []byte) error {
+	return i.ref().unmarshalText(text)
+}
 
-	return u.Uint32
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (i Int8) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.ref().marshalXML(e, start)
 }
 
-// Value implements the driver Valuer interface.
-func (u Uint32) Value() (driver.Value, error) {
-	if !u.Valid {
-		return nil, nil
-	}
-	return int64(u.Uint32), nil
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (i *Int8) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.ref().unmarshalXML(d, start)
 }
 
-// Scan implements the Scanner interface.
-func (u *Uint32) Scan(value interface{}) (err error) {
-	if value == nil {
-		u.Uint32, u.Valid = 0, false
-		return nil
-	}
-	u.Valid = true
-
-	var tmp uint64
-	switch v := value.(type) {
-	case []byte:
-		tmp, err = strconv.ParseUint(string(v), 10, 32)
-		if tmp > 4294967295 {
-			return errors.New("nilt: value passed to Uint32.Scan is out of range")
-		}
-		u.Uint32 = uint32(tmp)
-	case string:
-		tmp, err = strconv.ParseUint(v, 10, 32)
-		if tmp > 4294967295 {
-			return errors.New("nilt: value passed to Uint32.Scan is out of range")
-		}
-		u.Uint32 = uint32(tmp)
-	case int64:
-		u.Uint32 = uint32(v)
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Uint32.Scan", value)
-	}
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (i Int8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.ref().marshalXMLAttr(name)
+}
 
-	return
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (i *Int8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.ref().unmarshalXMLAttr(attr)
 }
 
-// MarshalJSON implements json.Marshaler interface.
-func (u *Uint32) MarshalJSON() ([]byte, error) {
-	if u == nil || !u.Valid {
-		return []byte("null"), nil
-	}
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (i Int8) MarshalBinary() ([]byte, error) {
+	return i.ref().appendBinary(nil)
+}
 
-	return json.Marshal(u.Uint32)
+// AppendBinary implements encoding.BinaryAppender interface.
+func (i Int8) AppendBinary(b []byte) ([]byte, error) {
+	return i.ref().appendBinary(b)
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
-func (u *Uint32) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		u.Uint32, u.Valid = 0, false
-		return nil
-	}
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (i *Int8) UnmarshalBinary(data []byte) error {
+	return i.ref().unmarshalBinary(data)
+}
 
-	u.Valid = true
+// GobEncode implements gob.GobEncoder interface.
+func (i Int8) GobEncode() ([]byte, error) {
+	return i.ref().gobEncode()
+}
 
-	return json.Unmarshal(data, &u.Uint32)
+// GobDecode implements gob.GobDecoder interface.
+func (i *Int8) GobDecode(data []byte) error {
+	return i.ref().gobDecode(data)
 }
 
 // Appear implements pqcomp Appearer interface.
-func (u *Uint32) Appear() bool {
-	return u != nil && u.Valid
+func (i *Int8) Appear() bool {
+	return i.ref().appear()
 }
 
-// Float32 represents a flaot64 that may be nil.
-type Float32 struct {
-	Float32 float32 `protobuf:"fixed64,1,opt,name=value" json:"value,omitempty"`
-	Valid   bool    `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+// Int16 represents a int16 that may be nil.
+type Int16 struct {
+	Int16 int16 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool  `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
 }
 
 // Reset implements proto.Message interface.
-func (f *Float32) Reset() { *f = Float32{} }
+func (i *Int16) Reset() { *i = Int16{} }
 
 // String implements proto.Message interface.
-func (f *Float32) String() string { return proto.CompactTextString(f) }
+func (i *Int16) String() string { return compactText(i.ProtoReflect()) }
 
 // ProtoMessage implements proto.Message interface.
-func (*Float32) ProtoMessage() {}
+func (*Int16) ProtoMessage() {}
 
-// Float32Or returns given Float32 value if receiver is nil or invalid.
-func (f *Float32) Float32Or(or float32) float32 {
-	if f == nil {
-		return or
-	}
-	if !f.Valid {
-		return or
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (i *Int16) ProtoReflect() protoreflect.Message { return int16Type.message(i) }
+
+func (i *Int16) ref() ref[int16] {
+	if i == nil {
+		return ref[int16]{}
 	}
+	return ref[int16]{v: &i.Int16, valid: &i.Valid}
+}
+
+func (i *Int16) unwrap() (interface{}, bool) {
+	return i.ref().unwrap()
+}
 
-	return f.Float32
+// Int16Or returns given int16 value if receiver is nil or invalid.
+func (i *Int16) Int16Or(or int16) int16 {
+	return i.ref().or(or)
 }
 
 // Value implements the driver Valuer interface.
-func (f Float32) Value() (driver.Value, error) {
-	if !f.Valid {
-		return nil, nil
-	}
-	return f.Float32, nil
+func (i Int16) Value() (driver.Value, error) {
+	return i.ref().value()
 }
 
 // Scan implements the Scanner interface.
-func (f *Float32) Scan(value interface{}) (err error) {
-	if value == nil {
-		f.Float32, f.Valid = 0.0, false
-		return nil
-	}
-	f.Valid = true
-
-	var tmp float64
-	switch v := value.(type) {
-	case []byte:
-		tmp, err = strconv.ParseFloat(string(v), 32)
-		f.Float32 = float32(tmp)
-	case string:
-		tmp, err = strconv.ParseFloat(v, 32)
-		f.Float32 = float32(tmp)
-	case float32:
-		f.Float32 = v
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Float32.Scan", value)
-	}
-
-	return
+func (i *Int16) Scan(value interface{}) error {
+	return i.ref().scan(value)
 }
 
 // MarshalJSON implements json.Marshaler interface.
-func (f *Float32) MarshalJSON() ([]byte, error) {
-	if f == nil || !f.Valid {
-		return []byte("null"), nil
-	}
-
-	return json.Marshal(f.Float32)
+func (i Int16) MarshalJSON() ([]byte, error) {
+	return i.ref().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
-func (f *Float32) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		f.Float32, f.Valid = 0, false
-		return nil
-	}
-
-	f.Valid = true
-
-	return json.Unmarshal(data, &f.Float32)
+func (i *Int16) UnmarshalJSON(data []byte) error {
+	return i.ref().unmarshalJSON(data)
 }
 
-// Appear implements pqcomp Appearer interface.
-func (f *Float32) Appear() bool {
-	return f != nil && f.Valid
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (i Int16) AppendJSON(dst []byte) ([]byte, error) {
+	return i.ref().appendJSON(dst)
 }
 
-// Float64 represents a flaot64 that may be nil.
-type Float64 struct {
-	Float64 float64 `protobuf:"fixed64,1,opt,name=value" json:"value,omitempty"`
-	Valid   bool    `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+// IsZero reports whether the value is invalid.
+func (i Int16) IsZero() bool {
+	return i.ref().isZero()
 }
 
-// Reset implements proto.Message interface.
-func (f *Float64) Reset() { *f = Float64{} }
-
-// String implements proto.Message interface.
-func (f *Float64) String() string { return proto.CompactTextString(f) }
-
-// ProtoMessage implements proto.Message interface.
-func (*Float64) ProtoMessage() {}
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (i Int16) MarshalText() ([]byte, error) {
+	return i.ref().marshalText()
+}
 
-// Float64Or returns given float64 value if receiver is nil or invalid.
-func (f *Float64) Float64Or(or float64) float64 {
-	if f == nil {
-		return or
-	}
-	if !f.Valid {
-		return or
-	}
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (i *Int16) UnmarshalText(text []byte) error {
+	return i.ref().unmarshalText(text)
+}
 
-	return f.Float64
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (i Int16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.ref().marshalXML(e, start)
 }
 
-// Value implements the driver Valuer interface.
-func (f Float64) Value() (driver.Value, error) {
-	if !f.Valid {
-		return nil, nil
-	}
-	return f.Float64, nil
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (i *Int16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.ref().unmarshalXML(d, start)
 }
 
-// Scan implements the Scanner interface.
-func (f *Float64) Scan(value interface{}) (err error) {
-	if value == nil {
-		f.Float64, f.Valid = 0.0, false
-		return nil
-	}
-	f.Valid = true
-
-	switch v := value.(type) {
-	case []byte:
-		f.Float64, err = strconv.ParseFloat(string(v), 64)
-	case string:
-		f.Float64, err = strconv.ParseFloat(v, 64)
-	case float64:
-		f.Float64 = v
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Float64.Scan", value)
-	}
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (i Int16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.ref().marshalXMLAttr(name)
+}
 
-	return
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (i *Int16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.ref().unmarshalXMLAttr(attr)
 }
 
-// MarshalJSON implements json.Marshaler interface.
-func (f *Float64) MarshalJSON() ([]byte, error) {
-	if f == nil || !f.Valid {
-		return []byte("null"), nil
-	}
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (i Int16) MarshalBinary() ([]byte, error) {
+	return i.ref().appendBinary(nil)
+}
 
-	return json.Marshal(f.Float64)
+// AppendBinary implements encoding.BinaryAppender interface.
+func (i Int16) AppendBinary(b []byte) ([]byte, error) {
+	return i.ref().appendBinary(b)
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
-func (f *Float64) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		f.Float64, f.Valid = 0, false
-		return nil
-	}
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (i *Int16) UnmarshalBinary(data []byte) error {
+	return i.ref().unmarshalBinary(data)
+}
 
-	f.Valid = true
+// GobEncode implements gob.GobEncoder interface.
+func (i Int16) GobEncode() ([]byte, error) {
+	return i.ref().gobEncode()
+}
 
-	return json.Unmarshal(data, &f.Float64)
+// GobDecode implements gob.GobDecoder interface.
+func (i *Int16) GobDecode(data []byte) error {
+	return i.ref().gobDecode(data)
 }
 
 // Appear implements pqcomp Appearer interface.
-func (f *Float64) Appear() bool {
-	return f != nil && f.Valid
+func (i *Int16) Appear() bool {
+	return i.ref().appear()
 }
 
-// Bool represents a bool that may be nil.
-type Bool struct {
-	Bool  bool `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
-	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+// Int32 represents a int32 that may be nil.
+type Int32 struct {
+	Int32 int32 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool  `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
 }
 
 // Reset implements proto.Message interface.
-func (b *Bool) Reset() { *b = Bool{} }
+func (ni *Int32) Reset() { *ni = Int32{} }
 
 // String implements proto.Message interface.
-func (b *Bool) String() string { return proto.CompactTextString(b) }
+func (ni *Int32) String() string { return compactText(ni.ProtoReflect()) }
 
 // ProtoMessage implements proto.Message interface.
-func (*Bool) ProtoMessage() {}
+func (*Int32) ProtoMessage() {}
 
-// BoolOr returns given bool value if receiver is nil or invalid.
-func (b *Bool) BoolOr(or bool) bool {
-	if b == nil {
-		return or
-	}
-	if !b.Valid {
-		return or
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (ni *Int32) ProtoReflect() protoreflect.Message { return int32Type.message(ni) }
+
+func (i *Int32) ref() ref[int32] {
+	if i == nil {
+		return ref[int32]{}
 	}
+	return ref[int32]{v: &i.Int32, valid: &i.Valid}
+}
 
-	return b.Bool
+func (i *Int32) unwrap() (interface{}, bool) {
+	return i.ref().unwrap()
+}
+
+// Int32Or returns given int32 value if receiver is nil or invalid.
+func (i *Int32) Int32Or(or int32) int32 {
+	return i.ref().or(or)
 }
 
 // Value implements the driver Valuer interface.
-func (f Bool) Value() (driver.Value, error) {
-	if !f.Valid {
-		return nil, nil
-	}
-	return f.Bool, nil
+func (i Int32) Value() (driver.Value, error) {
+	return i.ref().value()
 }
 
 // Scan implements the Scanner interface.
-func (b *Bool) Scan(value interface{}) (err error) {
-	if value == nil {
-		b.Bool, b.Valid = false, false
-		return nil
-	}
-	b.Valid = true
-
-	switch v := value.(type) {
-	case []byte:
-		b.Bool, err = strconv.ParseBool(string(v))
-	case string:
-		b.Bool, err = strconv.ParseBool(v)
-	case bool:
-		b.Bool = v
-	default:
-		err = fmt.Errorf("nilt: unsuported type (%T) passed to Bool.Scan", value)
-	}
-
-	return
+func (i *Int32) Scan(value interface{}) error {
+	return i.ref().scan(value)
 }
 
 // MarshalJSON implements json.Marshaler interface.
-func (b *Bool) MarshalJSON() ([]byte, error) {
-	if b == nil || !b.Valid {
-		return []byte("null"), nil
-	}
-
-	return json.Marshal(b.Bool)
+func (i Int32) MarshalJSON() ([]byte, error) {
+	return i.ref().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
-func (b *Bool) UnmarshalJSON(data []byte) error {
-	if data == nil {
-		b.Bool, b.Valid = false, false
-		return nil
-	}
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	return i.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (i Int32) AppendJSON(dst []byte) ([]byte, error) {
+	return i.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (i Int32) IsZero() bool {
+	return i.ref().isZero()
+}
 
-	b.Valid = true
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (i Int32) MarshalText() ([]byte, error) {
+	return i.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (i *Int32) UnmarshalText(text []byte) error {
+	return i.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (i Int32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (i *Int32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (i Int32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (i *Int32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (i Int32) MarshalBinary() ([]byte, error) {
+	return i.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (i Int32) AppendBinary(b []byte) ([]byte, error) {
+	return i.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (i *Int32) UnmarshalBinary(data []byte) error {
+	return i.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (i Int32) GobEncode() ([]byte, error) {
+	return i.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (i *Int32) GobDecode(data []byte) error {
+	return i.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (i *Int32) Appear() bool {
+	return i.ref().appear()
+}
+
+// Int represents a int that may be nil.
+type Int struct {
+	Int   int  `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (i *Int) Reset() { *i = Int{} }
+
+// String implements proto.Message interface.
+func (i *Int) String() string { return compactText(i.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Int) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (i *Int) ProtoReflect() protoreflect.Message { return intType.message(i) }
+
+func (i *Int) ref() ref[int] {
+	if i == nil {
+		return ref[int]{}
+	}
+	return ref[int]{v: &i.Int, valid: &i.Valid}
+}
+
+func (i *Int) unwrap() (interface{}, bool) {
+	return i.ref().unwrap()
+}
+
+// IntOr returns given int value if receiver is nil or invalid.
+func (i *Int) IntOr(or int) int {
+	return i.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (i Int) Value() (driver.Value, error) {
+	return i.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (i *Int) Scan(value interface{}) error {
+	return i.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return i.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	return i.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (i Int) AppendJSON(dst []byte) ([]byte, error) {
+	return i.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (i Int) IsZero() bool {
+	return i.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (i Int) MarshalText() ([]byte, error) {
+	return i.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (i *Int) UnmarshalText(text []byte) error {
+	return i.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (i Int) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (i *Int) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (i Int) MarshalBinary() ([]byte, error) {
+	return i.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (i Int) AppendBinary(b []byte) ([]byte, error) {
+	return i.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (i *Int) UnmarshalBinary(data []byte) error {
+	return i.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (i Int) GobEncode() ([]byte, error) {
+	return i.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (i *Int) GobDecode(data []byte) error {
+	return i.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (i *Int) Appear() bool {
+	return i.ref().appear()
+}
+
+// Uint32 represents a uint32 that may be nil.
+type Uint32 struct {
+	Uint32 uint32 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid  bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (u *Uint32) Reset() { *u = Uint32{} }
+
+// String implements proto.Message interface.
+func (u *Uint32) String() string { return compactText(u.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Uint32) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (u *Uint32) ProtoReflect() protoreflect.Message { return uint32Type.message(u) }
+
+func (u *Uint32) ref() ref[uint32] {
+	if u == nil {
+		return ref[uint32]{}
+	}
+	return ref[uint32]{v: &u.Uint32, valid: &u.Valid}
+}
+
+func (u *Uint32) unwrap() (interface{}, bool) {
+	return u.ref().unwrap()
+}
+
+// Uint32Or returns given uint32 value if receiver is nil or invalid.
+func (u *Uint32) Uint32Or(or uint32) uint32 {
+	return u.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (u Uint32) Value() (driver.Value, error) {
+	return u.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint32) Scan(value interface{}) error {
+	return u.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (u Uint32) MarshalJSON() ([]byte, error) {
+	return u.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (u *Uint32) UnmarshalJSON(data []byte) error {
+	return u.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (u Uint32) AppendJSON(dst []byte) ([]byte, error) {
+	return u.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (u Uint32) IsZero() bool {
+	return u.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (u Uint32) MarshalText() ([]byte, error) {
+	return u.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (u *Uint32) UnmarshalText(text []byte) error {
+	return u.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (u Uint32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (u *Uint32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return u.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (u Uint32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (u *Uint32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (u Uint32) MarshalBinary() ([]byte, error) {
+	return u.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (u Uint32) AppendBinary(b []byte) ([]byte, error) {
+	return u.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (u *Uint32) UnmarshalBinary(data []byte) error {
+	return u.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (u Uint32) GobEncode() ([]byte, error) {
+	return u.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (u *Uint32) GobDecode(data []byte) error {
+	return u.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (u *Uint32) Appear() bool {
+	return u.ref().appear()
+}
+
+// Uint represents a uint that may be nil.
+type Uint struct {
+	Uint  uint `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (u *Uint) Reset() { *u = Uint{} }
+
+// String implements proto.Message interface.
+func (u *Uint) String() string { return compactText(u.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Uint) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (u *Uint) ProtoReflect() protoreflect.Message { return uintType.message(u) }
+
+func (u *Uint) ref() ref[uint] {
+	if u == nil {
+		return ref[uint]{}
+	}
+	return ref[uint]{v: &u.Uint, valid: &u.Valid}
+}
+
+func (u *Uint) unwrap() (interface{}, bool) {
+	return u.ref().unwrap()
+}
+
+// UintOr returns given uint value if receiver is nil or invalid.
+func (u *Uint) UintOr(or uint) uint {
+	return u.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+// Values greater than math.MaxInt64 are passed to the driver as decimal strings.
+func (u Uint) Value() (driver.Value, error) {
+	return u.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint) Scan(value interface{}) error {
+	return u.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (u Uint) MarshalJSON() ([]byte, error) {
+	return u.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (u *Uint) UnmarshalJSON(data []byte) error {
+	return u.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (u Uint) AppendJSON(dst []byte) ([]byte, error) {
+	return u.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (u Uint) IsZero() bool {
+	return u.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (u Uint) MarshalText() ([]byte, error) {
+	return u.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (u *Uint) UnmarshalText(text []byte) error {
+	return u.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (u Uint) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (u *Uint) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return u.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (u Uint) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (u *Uint) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (u Uint) MarshalBinary() ([]byte, error) {
+	return u.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (u Uint) AppendBinary(b []byte) ([]byte, error) {
+	return u.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (u *Uint) UnmarshalBinary(data []byte) error {
+	return u.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (u Uint) GobEncode() ([]byte, error) {
+	return u.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (u *Uint) GobDecode(data []byte) error {
+	return u.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (u *Uint) Appear() bool {
+	return u.ref().appear()
+}
+
+// Uint8 represents a uint8 that may be nil.
+type Uint8 struct {
+	Uint8 uint8 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool  `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (u *Uint8) Reset() { *u = Uint8{} }
+
+// String implements proto.Message interface.
+func (u *Uint8) String() string { return compactText(u.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Uint8) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (u *Uint8) ProtoReflect() protoreflect.Message { return uint8Type.message(u) }
+
+func (u *Uint8) ref() ref[uint8] {
+	if u == nil {
+		return ref[uint8]{}
+	}
+	return ref[uint8]{v: &u.Uint8, valid: &u.Valid}
+}
+
+func (u *Uint8) unwrap() (interface{}, bool) {
+	return u.ref().unwrap()
+}
+
+// Uint8Or returns given uint8 value if receiver is nil or invalid.
+func (u *Uint8) Uint8Or(or uint8) uint8 {
+	return u.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (u Uint8) Value() (driver.Value, error) {
+	return u.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint8) Scan(value interface{}) error {
+	return u.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (u Uint8) MarshalJSON() ([]byte, error) {
+	return u.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (u *Uint8) UnmarshalJSON(data []byte) error {
+	return u.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (u Uint8) AppendJSON(dst []byte) ([]byte, error) {
+	return u.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (u Uint8) IsZero() bool {
+	return u.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (u Uint8) MarshalText() ([]byte, error) {
+	return u.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (u *Uint8) UnmarshalText(text []byte) error {
+	return u.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (u Uint8) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (u *Uint8) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return u.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (u Uint8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (u *Uint8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (u Uint8) MarshalBinary() ([]byte, error) {
+	return u.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (u Uint8) AppendBinary(b []byte) ([]byte, error) {
+	return u.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (u *Uint8) UnmarshalBinary(data []byte) error {
+	return u.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (u Uint8) GobEncode() ([]byte, error) {
+	return u.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (u *Uint8) GobDecode(data []byte) error {
+	return u.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (u *Uint8) Appear() bool {
+	return u.ref().appear()
+}
+
+// Uint16 represents a uint16 that may be nil.
+type Uint16 struct {
+	Uint16 uint16 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid  bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (u *Uint16) Reset() { *u = Uint16{} }
+
+// String implements proto.Message interface.
+func (u *Uint16) String() string { return compactText(u.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Uint16) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (u *Uint16) ProtoReflect() protoreflect.Message { return uint16Type.message(u) }
+
+func (u *Uint16) ref() ref[uint16] {
+	if u == nil {
+		return ref[uint16]{}
+	}
+	return ref[uint16]{v: &u.Uint16, valid: &u.Valid}
+}
+
+func (u *Uint16) unwrap() (interface{}, bool) {
+	return u.ref().unwrap()
+}
+
+// Uint16Or returns given uint16 value if receiver is nil or invalid.
+func (u *Uint16) Uint16Or(or uint16) uint16 {
+	return u.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (u Uint16) Value() (driver.Value, error) {
+	return u.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint16) Scan(value interface{}) error {
+	return u.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (u Uint16) MarshalJSON() ([]byte, error) {
+	return u.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (u *Uint16) UnmarshalJSON(data []byte) error {
+	return u.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (u Uint16) AppendJSON(dst []byte) ([]byte, error) {
+	return u.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (u Uint16) IsZero() bool {
+	return u.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (u Uint16) MarshalText() ([]byte, error) {
+	return u.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (u *Uint16) UnmarshalText(text []byte) error {
+	return u.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (u Uint16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (u *Uint16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return u.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (u Uint16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (u *Uint16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (u Uint16) MarshalBinary() ([]byte, error) {
+	return u.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (u Uint16) AppendBinary(b []byte) ([]byte, error) {
+	return u.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (u *Uint16) UnmarshalBinary(data []byte) error {
+	return u.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (u Uint16) GobEncode() ([]byte, error) {
+	return u.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (u *Uint16) GobDecode(data []byte) error {
+	return u.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (u *Uint16) Appear() bool {
+	return u.ref().appear()
+}
+
+// Uint64 represents a uint64 that may be nil.
+type Uint64 struct {
+	Uint64 uint64 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid  bool   `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (u *Uint64) Reset() { *u = Uint64{} }
+
+// String implements proto.Message interface.
+func (u *Uint64) String() string { return compactText(u.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Uint64) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (u *Uint64) ProtoReflect() protoreflect.Message { return uint64Type.message(u) }
+
+func (u *Uint64) ref() ref[uint64] {
+	if u == nil {
+		return ref[uint64]{}
+	}
+	return ref[uint64]{v: &u.Uint64, valid: &u.Valid}
+}
+
+func (u *Uint64) unwrap() (interface{}, bool) {
+	return u.ref().unwrap()
+}
+
+// Uint64Or returns given uint64 value if receiver is nil or invalid.
+func (u *Uint64) Uint64Or(or uint64) uint64 {
+	return u.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+// Values greater than math.MaxInt64 are passed to the driver as decimal strings.
+func (u Uint64) Value() (driver.Value, error) {
+	return u.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint64) Scan(value interface{}) error {
+	return u.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Valid value is encoded as a string if Uint64JSONString is true.
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	return u.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	return u.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (u Uint64) AppendJSON(dst []byte) ([]byte, error) {
+	return u.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (u Uint64) IsZero() bool {
+	return u.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (u Uint64) MarshalText() ([]byte, error) {
+	return u.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (u *Uint64) UnmarshalText(text []byte) error {
+	return u.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (u Uint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (u *Uint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return u.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (u *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (u Uint64) MarshalBinary() ([]byte, error) {
+	return u.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (u Uint64) AppendBinary(b []byte) ([]byte, error) {
+	return u.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	return u.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (u Uint64) GobEncode() ([]byte, error) {
+	return u.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (u *Uint64) GobDecode(data []byte) error {
+	return u.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (u *Uint64) Appear() bool {
+	return u.ref().appear()
+}
+
+// Float32 represents a float32 that may be nil.
+type Float32 struct {
+	Float32 float32 `protobuf:"fixed32,1,opt,name=value" json:"value,omitempty"`
+	Valid   bool    `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (f *Float32) Reset() { *f = Float32{} }
+
+// String implements proto.Message interface.
+func (f *Float32) String() string { return compactText(f.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Float32) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (f *Float32) ProtoReflect() protoreflect.Message { return float32Type.message(f) }
+
+func (f *Float32) ref() ref[float32] {
+	if f == nil {
+		return ref[float32]{}
+	}
+	return ref[float32]{v: &f.Float32, valid: &f.Valid}
+}
+
+func (f *Float32) unwrap() (interface{}, bool) {
+	return f.ref().unwrap()
+}
+
+// Float32Or returns given Float32 value if receiver is nil or invalid.
+func (f *Float32) Float32Or(or float32) float32 {
+	return f.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (f Float32) Value() (driver.Value, error) {
+	return f.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (f *Float32) Scan(value interface{}) error {
+	return f.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (f Float32) MarshalJSON() ([]byte, error) {
+	return f.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (f *Float32) UnmarshalJSON(data []byte) error {
+	return f.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (f Float32) AppendJSON(dst []byte) ([]byte, error) {
+	return f.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (f Float32) IsZero() bool {
+	return f.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (f Float32) MarshalText() ([]byte, error) {
+	return f.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (f *Float32) UnmarshalText(text []byte) error {
+	return f.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (f Float32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return f.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (f *Float32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return f.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (f Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return f.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (f *Float32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (f Float32) MarshalBinary() ([]byte, error) {
+	return f.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (f Float32) AppendBinary(b []byte) ([]byte, error) {
+	return f.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (f *Float32) UnmarshalBinary(data []byte) error {
+	return f.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (f Float32) GobEncode() ([]byte, error) {
+	return f.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (f *Float32) GobDecode(data []byte) error {
+	return f.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (f *Float32) Appear() bool {
+	return f.ref().appear()
+}
+
+// Float64 represents a float64 that may be nil.
+type Float64 struct {
+	Float64 float64 `protobuf:"fixed64,1,opt,name=value" json:"value,omitempty"`
+	Valid   bool    `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (f *Float64) Reset() { *f = Float64{} }
+
+// String implements proto.Message interface.
+func (f *Float64) String() string { return compactText(f.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Float64) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (f *Float64) ProtoReflect() protoreflect.Message { return float64Type.message(f) }
+
+func (f *Float64) ref() ref[float64] {
+	if f == nil {
+		return ref[float64]{}
+	}
+	return ref[float64]{v: &f.Float64, valid: &f.Valid}
+}
+
+func (f *Float64) unwrap() (interface{}, bool) {
+	return f.ref().unwrap()
+}
+
+// Float64Or returns given float64 value if receiver is nil or invalid.
+func (f *Float64) Float64Or(or float64) float64 {
+	return f.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (f Float64) Value() (driver.Value, error) {
+	return f.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (f *Float64) Scan(value interface{}) error {
+	return f.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (f Float64) MarshalJSON() ([]byte, error) {
+	return f.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	return f.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (f Float64) AppendJSON(dst []byte) ([]byte, error) {
+	return f.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (f Float64) IsZero() bool {
+	return f.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (f Float64) MarshalText() ([]byte, error) {
+	return f.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (f *Float64) UnmarshalText(text []byte) error {
+	return f.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (f Float64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return f.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (f *Float64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return f.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return f.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (f *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (f Float64) MarshalBinary() ([]byte, error) {
+	return f.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (f Float64) AppendBinary(b []byte) ([]byte, error) {
+	return f.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	return f.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (f Float64) GobEncode() ([]byte, error) {
+	return f.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (f *Float64) GobDecode(data []byte) error {
+	return f.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (f *Float64) Appear() bool {
+	return f.ref().appear()
+}
+
+// Bool represents a bool that may be nil.
+type Bool struct {
+	Bool  bool `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (b *Bool) Reset() { *b = Bool{} }
+
+// String implements proto.Message interface.
+func (b *Bool) String() string { return compactText(b.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Bool) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (b *Bool) ProtoReflect() protoreflect.Message { return boolType.message(b) }
+
+func (b *Bool) ref() ref[bool] {
+	if b == nil {
+		return ref[bool]{}
+	}
+	return ref[bool]{v: &b.Bool, valid: &b.Valid}
+}
+
+func (b *Bool) unwrap() (interface{}, bool) {
+	return b.ref().unwrap()
+}
+
+// BoolOr returns given bool value if receiver is nil or invalid.
+func (b *Bool) BoolOr(or bool) bool {
+	return b.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (b Bool) Value() (driver.Value, error) {
+	return b.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (b *Bool) Scan(value interface{}) error {
+	return b.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	return b.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	return b.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (b Bool) AppendJSON(dst []byte) ([]byte, error) {
+	return b.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (b Bool) IsZero() bool {
+	return b.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (b Bool) MarshalText() ([]byte, error) {
+	return b.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (b *Bool) UnmarshalText(text []byte) error {
+	return b.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (b Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return b.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return b.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return b.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (b Bool) MarshalBinary() ([]byte, error) {
+	return b.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (b Bool) AppendBinary(dst []byte) ([]byte, error) {
+	return b.ref().appendBinary(dst)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	return b.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (b Bool) GobEncode() ([]byte, error) {
+	return b.ref().gobEncode()
+}
 
-	return json.Unmarshal(data, &b.Bool)
+// GobDecode implements gob.GobDecoder interface.
+func (b *Bool) GobDecode(data []byte) error {
+	return b.ref().gobDecode(data)
 }
 
 // Appear implements pqcomp Appearer interface.
 func (b *Bool) Appear() bool {
-	return b != nil && b.Valid
+	return b.ref().appear()
 }
@@ -1,12 +1,33 @@
 package nilt_test
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"encoding/gob"
 	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/niltpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestInt64_ProtoMessage(t *testing.T) {
@@ -268,3 +289,2013 @@ WithinLoop:
 		t.Logf("within: %s: %s", d, string(b))
 	}
 }
+
+func TestOf_Or(t *testing.T) {
+	var n *nilt.Of[int64]
+	if got := n.Or(5); got != 5 {
+		t.Errorf("nil: wrong output, expected 5 but got %d", got)
+	}
+	if got := (&nilt.Of[int64]{V: 1}).Or(5); got != 5 {
+		t.Errorf("invalid: wrong output, expected 5 but got %d", got)
+	}
+	if got := (&nilt.Of[int64]{V: 1, Valid: true}).Or(5); got != 1 {
+		t.Errorf("valid: wrong output, expected 1 but got %d", got)
+	}
+}
+
+func TestOf_Scan(t *testing.T) {
+	var s nilt.Of[string]
+	if err := s.Scan([]byte("text")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !s.Valid || s.V != "text" {
+		t.Errorf("wrong output, expected valid text but got %#v", s)
+	}
+	if err := s.Scan(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if s.Valid || s.V != "" {
+		t.Errorf("wrong output, expected invalid empty string but got %#v", s)
+	}
+
+	failure := map[string]interface{}{
+		"negative": int64(-1),
+		"overflow": int64(4294967296),
+		"string":   "-1",
+		"type":     true,
+	}
+	for d, given := range failure {
+		u := nilt.Of[uint32]{V: 1, Valid: true}
+		if err := u.Scan(given); err == nil {
+			t.Errorf("%s: expected error", d)
+		}
+		if u.Valid {
+			t.Errorf("%s: expected invalid value after failed scan", d)
+		}
+	}
+}
+
+func TestOf_Value(t *testing.T) {
+	cases := map[string]struct {
+		given    driver.Valuer
+		expected driver.Value
+	}{
+		"invalid":    {given: nilt.Of[int32]{V: 1}, expected: nil},
+		"int32":      {given: nilt.Of[int32]{V: 1, Valid: true}, expected: int64(1)},
+		"int":        {given: nilt.Of[int]{V: 1, Valid: true}, expected: int64(1)},
+		"uint32":     {given: nilt.Of[uint32]{V: 1, Valid: true}, expected: int64(1)},
+		"float32":    {given: nilt.Of[float32]{V: 1.5, Valid: true}, expected: float64(1.5)},
+		"named type": {given: nilt.Int{Int: 1, Valid: true}, expected: int64(1)},
+	}
+
+	for d, c := range cases {
+		got, err := c.given.Value()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, got)
+		}
+	}
+}
+
+func TestOf_MarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		given    json.Marshaler
+		expected string
+	}{
+		"nil":     {given: (*nilt.Of[bool])(nil), expected: "null"},
+		"invalid": {given: &nilt.Of[bool]{V: true}, expected: "null"},
+		"bool":    {given: &nilt.Of[bool]{V: true, Valid: true}, expected: "true"},
+		"string":  {given: &nilt.Of[string]{V: "text", Valid: true}, expected: `"text"`},
+		"float64": {given: &nilt.Of[float64]{V: 1.5, Valid: true}, expected: "1.5"},
+	}
+
+	for d, c := range cases {
+		b, err := json.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		if string(b) != c.expected {
+			t.Errorf("%s: wrong output, expected %s but got %s", d, c.expected, string(b))
+		}
+	}
+}
+
+func TestTime_ProtoMessage(t *testing.T) {
+	success := []nilt.Time{
+		{Time: time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC), Valid: true},
+		{Time: time.Date(1960, 1, 1, 0, 0, 0, 1, time.UTC), Valid: true},
+		{Time: time.Unix(0, 0).UTC(), Valid: true},
+		{Time: time.Time{}, Valid: true},
+		{Time: time.Time{}, Valid: false},
+		{Time: time.Date(2016, 4, 24, 0, 0, 0, 0, time.UTC), Valid: false},
+	}
+
+	for _, given := range success {
+		buf, err := proto.Marshal(&given)
+		if err != nil {
+			t.Errorf("marshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		var tmp nilt.Time
+		if err = proto.Unmarshal(buf, &tmp); err != nil {
+			t.Errorf("unmarshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		if !tmp.Time.Equal(given.Time) {
+			t.Errorf("times are not equal expected %s, got %s", given.Time, tmp.Time)
+		}
+		if tmp.Valid != given.Valid {
+			t.Errorf("booleans are not equal expected %t, got %t", given.Valid, tmp.Valid)
+		}
+	}
+}
+
+func TestTime_Marshal_timestamp(t *testing.T) {
+	given := time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC)
+	buf, err := proto.Marshal(&nilt.Time{Time: given, Valid: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	num, typ, n := protowire.ConsumeTag(buf)
+	if num != 1 || typ != protowire.BytesType {
+		t.Fatalf("wrong first field, expected 1 of bytes type but got %d of %d type", num, typ)
+	}
+	ts, _ := protowire.ConsumeBytes(buf[n:])
+
+	var got timestamppb.Timestamp
+	if err = proto.Unmarshal(ts, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !got.AsTime().Equal(given) {
+		t.Errorf("wrong timestamp, expected %s but got %s", given, got.AsTime())
+	}
+}
+
+func TestTime_Scan(t *testing.T) {
+	expected := time.Date(2016, 4, 24, 12, 30, 15, 123000000, time.UTC)
+	success := map[string]interface{}{
+		"time":   expected,
+		"string": "2016-04-24T12:30:15.123Z",
+		"bytes":  []byte("2016-04-24T14:30:15.123+02:00"),
+	}
+
+	for d, given := range success {
+		var tm nilt.Time
+		if err := tm.Scan(given); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !tm.Valid || !tm.Time.Equal(expected) {
+			t.Errorf("%s: wrong output, expected valid %s but got %#v", d, expected, tm)
+		}
+	}
+
+	var tm nilt.Time
+	if err := tm.Scan(int64(1)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		given    *nilt.Time
+		expected string
+	}{
+		"nil":     {given: nil, expected: "null"},
+		"invalid": {given: &nilt.Time{Time: time.Unix(1, 0)}, expected: "null"},
+		"valid": {
+			given:    &nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC), Valid: true},
+			expected: `"2016-04-24T12:30:15.123456789Z"`,
+		},
+	}
+
+	for d, c := range cases {
+		b, err := json.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		if string(b) != c.expected {
+			t.Errorf("%s: wrong output, expected %s but got %s", d, c.expected, string(b))
+		}
+	}
+}
+
+func TestTime_String(t *testing.T) {
+	cases := map[string]struct {
+		given    nilt.Time
+		expected string
+	}{
+		"zero":    {given: nilt.Time{}, expected: ""},
+		"valid":   {given: nilt.Time{Time: time.Unix(5, 1), Valid: true}, expected: "value:<seconds:5 nanos:1 > valid:true "},
+		"invalid": {given: nilt.Time{Time: time.Unix(5, 0)}, expected: "value:<seconds:5 > "},
+	}
+
+	for d, c := range cases {
+		if got := c.given.String(); got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", d, c.expected, got)
+		}
+	}
+}
+
+func TestDuration_ProtoMessage(t *testing.T) {
+	success := []nilt.Duration{
+		{Duration: 90 * time.Minute, Valid: true},
+		{Duration: -1500 * time.Millisecond, Valid: true},
+		{Duration: 1, Valid: true},
+		{Duration: 0, Valid: true},
+		{Duration: 0, Valid: false},
+		{Duration: time.Hour, Valid: false},
+	}
+
+	for _, given := range success {
+		buf, err := proto.Marshal(&given)
+		if err != nil {
+			t.Errorf("marshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		var tmp nilt.Duration
+		if err = proto.Unmarshal(buf, &tmp); err != nil {
+			t.Errorf("unmarshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		if tmp != given {
+			t.Errorf("durations are not equal expected %#v, got %#v", given, tmp)
+		}
+	}
+}
+
+func TestDuration_Marshal_duration(t *testing.T) {
+	given := -1500 * time.Millisecond
+	buf, err := proto.Marshal(&nilt.Duration{Duration: given, Valid: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	_, _, n := protowire.ConsumeTag(buf)
+	v, _ := protowire.ConsumeBytes(buf[n:])
+
+	var got durationpb.Duration
+	if err = proto.Unmarshal(v, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got.AsDuration() != given {
+		t.Errorf("wrong duration, expected %s but got %s", given, got.AsDuration())
+	}
+}
+
+func TestDuration_Scan(t *testing.T) {
+	success := map[string]struct {
+		given    interface{}
+		expected time.Duration
+	}{
+		"int64":              {given: int64(1500), expected: 1500},
+		"nanoseconds":        {given: []byte("1500"), expected: 1500},
+		"go duration":        {given: "1h30m", expected: 90 * time.Minute},
+		"interval time":      {given: "01:30:00.5", expected: 90*time.Minute + 500*time.Millisecond},
+		"interval days":      {given: []byte("3 days"), expected: 72 * time.Hour},
+		"interval negative":  {given: "-1 days +02:00:00", expected: -22 * time.Hour},
+		"interval full":      {given: "1 year 2 mons 3 days 04:05:06", expected: 8766*time.Hour + 60*24*time.Hour + 76*time.Hour + 5*time.Minute + 6*time.Second},
+		"interval neg. time": {given: "-00:00:01.25", expected: -1250 * time.Millisecond},
+	}
+
+	for d, c := range success {
+		var dur nilt.Duration
+		if err := dur.Scan(c.given); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !dur.Valid || dur.Duration != c.expected {
+			t.Errorf("%s: wrong output, expected valid %s but got %#v", d, c.expected, dur)
+		}
+	}
+
+	failure := []interface{}{"1 fortnight", "1:2", "abc", 1.5}
+	for _, given := range failure {
+		var dur nilt.Duration
+		if err := dur.Scan(given); err == nil {
+			t.Errorf("%v: expected error", given)
+		}
+	}
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	given := &nilt.Duration{Duration: 90 * time.Minute, Valid: true}
+
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(b) != `"1h30m0s"` {
+		t.Errorf("wrong output, expected %s but got %s", `"1h30m0s"`, string(b))
+	}
+
+	nilt.DurationJSONFormat = nilt.DurationFormatNanoseconds
+	defer func() { nilt.DurationJSONFormat = nilt.DurationFormatString }()
+
+	b, err = json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(b) != "5400000000000" {
+		t.Errorf("wrong output, expected %s but got %s", "5400000000000", string(b))
+	}
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	for _, given := range []string{`"1h30m0s"`, "5400000000000"} {
+		var d nilt.Duration
+		if err := json.Unmarshal([]byte(given), &d); err != nil {
+			t.Errorf("%s: unexpected error: %s", given, err.Error())
+			continue
+		}
+		if !d.Valid || d.Duration != 90*time.Minute {
+			t.Errorf("%s: wrong output, got %#v", given, d)
+		}
+	}
+}
+
+func TestBytes_ProtoMessage(t *testing.T) {
+	success := []nilt.Bytes{
+		{Bytes: []byte("text"), Valid: true},
+		{Bytes: []byte{0, 1, 2}, Valid: false},
+		{Bytes: nil, Valid: true},
+		{Bytes: nil, Valid: false},
+	}
+
+	for _, given := range success {
+		buf, err := proto.Marshal(&given)
+		if err != nil {
+			t.Errorf("marshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		var tmp nilt.Bytes
+		if err = proto.Unmarshal(buf, &tmp); err != nil {
+			t.Errorf("unmarshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		if !bytes.Equal(tmp.Bytes, given.Bytes) {
+			t.Errorf("bytes are not equal expected %v, got %v", given.Bytes, tmp.Bytes)
+		}
+		if tmp.Valid != given.Valid {
+			t.Errorf("booleans are not equal expected %t, got %t", given.Valid, tmp.Valid)
+		}
+	}
+}
+
+func TestBytes_Scan(t *testing.T) {
+	buf := []byte("text")
+
+	var b nilt.Bytes
+	if err := b.Scan(buf); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	buf[0] = 'n'
+	if !b.Valid || string(b.Bytes) != "text" {
+		t.Errorf("wrong output, expected valid copy of the buffer but got %#v", b)
+	}
+
+	if err := b.Scan([]byte{}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !b.Valid || b.Bytes == nil {
+		t.Errorf("wrong output, expected valid empty slice but got %#v", b)
+	}
+
+	if err := b.Scan(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if b.Valid || b.Bytes != nil {
+		t.Errorf("wrong output, expected invalid nil slice but got %#v", b)
+	}
+}
+
+func TestBytes_Value(t *testing.T) {
+	v, err := nilt.Bytes{Valid: true}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if b, ok := v.([]byte); !ok || b == nil {
+		t.Errorf("wrong output, expected empty slice but got %#v", v)
+	}
+
+	v, err = nilt.Bytes{Bytes: []byte{}}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if v != nil {
+		t.Errorf("wrong output, expected nil but got %#v", v)
+	}
+}
+
+func TestBytes_MarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		given    *nilt.Bytes
+		expected string
+	}{
+		"nil":     {given: nil, expected: "null"},
+		"invalid": {given: &nilt.Bytes{Bytes: []byte("text")}, expected: "null"},
+		"empty":   {given: &nilt.Bytes{Valid: true}, expected: `""`},
+		"valid":   {given: &nilt.Bytes{Bytes: []byte("text"), Valid: true}, expected: `"dGV4dA=="`},
+	}
+
+	for d, c := range cases {
+		b, err := json.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+
+		if string(b) != c.expected {
+			t.Errorf("%s: wrong output, expected %s but got %s", d, c.expected, string(b))
+		}
+	}
+}
+
+func TestUint8_ProtoMessage(t *testing.T) {
+	success := []nilt.Uint8{
+		{Uint8: 1, Valid: true},
+		{Uint8: 255, Valid: true},
+		{Uint8: 0, Valid: false},
+		{Uint8: 13, Valid: false},
+	}
+
+	for _, given := range success {
+		buf, err := proto.Marshal(&given)
+		if err != nil {
+			t.Errorf("marshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		var tmp nilt.Uint8
+		if err = proto.Unmarshal(buf, &tmp); err != nil {
+			t.Errorf("unmarshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		if tmp != given {
+			t.Errorf("values are not equal expected %#v, got %#v", given, tmp)
+		}
+	}
+
+	buf, err := proto.Marshal(&nilt.Uint16{Uint16: 256, Valid: true})
+	if err != nil {
+		t.Fatalf("marshal returned unexpected error: %s", err.Error())
+	}
+	if err = proto.Unmarshal(buf, &nilt.Uint8{}); err == nil {
+		t.Error("unmarshal of out of range value expected to return an error")
+	}
+}
+
+func TestUint64_Scan(t *testing.T) {
+	success := map[uint64]interface{}{
+		100:                  int64(100),
+		9223372036854775807:  int64(9223372036854775807),
+		18446744073709551615: "18446744073709551615",
+		0:                    []byte("0"),
+	}
+
+	for expected, given := range success {
+		var u nilt.Uint64
+		if err := u.Scan(given); err != nil {
+			t.Errorf("%v: unexpected error: %s", given, err.Error())
+			continue
+		}
+		if !u.Valid || u.Uint64 != expected {
+			t.Errorf("%v: wrong output, expected %d but got %#v", given, expected, u)
+		}
+	}
+
+	var u nilt.Uint64
+	if err := u.Scan(int64(-1)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUint16_Scan(t *testing.T) {
+	failure := []interface{}{int64(-1), int64(65536), "65536", []byte("-1")}
+
+	for _, given := range failure {
+		var u nilt.Uint16
+		if err := u.Scan(given); err == nil {
+			t.Errorf("%v: expected error", given)
+		}
+	}
+}
+
+func TestUint64_Value(t *testing.T) {
+	cases := map[string]struct {
+		given    nilt.Uint64
+		expected driver.Value
+	}{
+		"invalid": {given: nilt.Uint64{Uint64: 1}, expected: nil},
+		"small":   {given: nilt.Uint64{Uint64: 1, Valid: true}, expected: int64(1)},
+		"big":     {given: nilt.Uint64{Uint64: 18446744073709551615, Valid: true}, expected: "18446744073709551615"},
+	}
+
+	for d, c := range cases {
+		got, err := c.given.Value()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, got)
+		}
+	}
+}
+
+func TestUint64_MarshalJSON(t *testing.T) {
+	given := &nilt.Uint64{Uint64: 18446744073709551615, Valid: true}
+
+	nilt.Uint64JSONString = true
+	defer func() { nilt.Uint64JSONString = false }()
+
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(b) != `"18446744073709551615"` {
+		t.Errorf("wrong output, expected %s but got %s", `"18446744073709551615"`, string(b))
+	}
+
+	var got nilt.Uint64
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got != *given {
+		t.Errorf("wrong output, expected %#v but got %#v", *given, got)
+	}
+}
+
+func TestInt8_ProtoMessage(t *testing.T) {
+	success := []nilt.Int8{
+		{Int8: 1, Valid: true},
+		{Int8: -128, Valid: true},
+		{Int8: 127, Valid: false},
+		{Int8: 0, Valid: false},
+	}
+
+	for _, given := range success {
+		buf, err := proto.Marshal(&given)
+		if err != nil {
+			t.Errorf("marshal returned unexpected error: %s", err.Error())
+			continue
+		}
+
+		var tmp nilt.Int32
+		if err = proto.Unmarshal(buf, &tmp); err != nil {
+			t.Errorf("unmarshal into int32 returned unexpected error: %s", err.Error())
+			continue
+		}
+		if tmp.Int32 != int32(given.Int8) || tmp.Valid != given.Valid {
+			t.Errorf("int32 is not equal expected %#v, got %#v", given, tmp)
+		}
+
+		var got nilt.Int8
+		if err = proto.Unmarshal(buf, &got); err != nil {
+			t.Errorf("unmarshal returned unexpected error: %s", err.Error())
+			continue
+		}
+		if got != given {
+			t.Errorf("values are not equal expected %#v, got %#v", given, got)
+		}
+	}
+
+	buf, err := proto.Marshal(&nilt.Int32{Int32: -129, Valid: true})
+	if err != nil {
+		t.Fatalf("marshal returned unexpected error: %s", err.Error())
+	}
+	if err = proto.Unmarshal(buf, &nilt.Int8{}); err == nil {
+		t.Error("unmarshal of out of range value expected to return an error")
+	}
+}
+
+func TestInt16_Scan(t *testing.T) {
+	success := map[int16]interface{}{
+		-32768: int64(-32768),
+		32767:  "32767",
+		0:      []byte("0"),
+	}
+
+	for expected, given := range success {
+		var i nilt.Int16
+		if err := i.Scan(given); err != nil {
+			t.Errorf("%v: unexpected error: %s", given, err.Error())
+			continue
+		}
+		if !i.Valid || i.Int16 != expected {
+			t.Errorf("%v: wrong output, expected %d but got %#v", given, expected, i)
+		}
+	}
+
+	failure := []interface{}{int64(-32769), int64(32768), "32768", []byte("-32769")}
+	for _, given := range failure {
+		var i nilt.Int16
+		if err := i.Scan(given); err == nil {
+			t.Errorf("%v: expected error", given)
+		}
+	}
+}
+
+func TestProto_symmetry(t *testing.T) {
+	for _, e := range examples {
+		m, ok := e.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e).(proto.Message)
+		if err = proto.Unmarshal(b, got); err != nil {
+			t.Errorf("%T: unexpected error for %v: %s", e, b, err.Error())
+			continue
+		}
+		if !proto.Equal(got, m) {
+			t.Errorf("%T: wrong output for %v, expected %v but got %v", e, b, m, got)
+		}
+	}
+}
+
+func TestProto_protojson(t *testing.T) {
+	for _, e := range examples {
+		m, ok := e.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e).(proto.Message)
+		if err = protojson.Unmarshal(b, got); err != nil {
+			t.Errorf("%T: unexpected error for %s: %s", e, b, err.Error())
+			continue
+		}
+		if !proto.Equal(got, m) {
+			t.Errorf("%T: wrong output for %s, expected %v but got %v", e, b, m, got)
+		}
+	}
+}
+
+func TestProto_outOfRange(t *testing.T) {
+	failure := map[string]struct {
+		given proto.Message
+		data  proto.Message
+	}{
+		"int8":     {given: &nilt.Int8{}, data: &niltpb.Int8{Value: 300, Valid: true}},
+		"int16":    {given: &nilt.Int16{}, data: &niltpb.Int16{Value: -32769}},
+		"uint8":    {given: &nilt.Uint8{}, data: &niltpb.Uint8{Value: 256, Valid: true}},
+		"uint16":   {given: &nilt.Uint16{}, data: &niltpb.Uint16{Value: 65536, Valid: true}},
+		"duration": {given: &nilt.Duration{}, data: &niltpb.Duration{Value: &durationpb.Duration{Seconds: 315576000000}, Valid: true}},
+	}
+
+	for d, c := range failure {
+		buf, err := proto.Marshal(c.data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", d, err.Error())
+		}
+		for _, opts := range []proto.UnmarshalOptions{{}, {AllowPartial: true}, {Merge: true}} {
+			if err := opts.Unmarshal(buf, c.given); err == nil {
+				t.Errorf("%s: expected error, got %v", d, c.given)
+			}
+		}
+		if _, err := proto.Marshal(c.given); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+		}
+	}
+
+	// protoreflect and protojson cannot report the error, the value is not stored
+	var i nilt.Int16
+	m := i.ProtoReflect()
+	m.Set(m.Descriptor().Fields().ByName("value"), protoreflect.ValueOfInt32(1<<20))
+	if i.Int16 != 0 {
+		t.Errorf("expected out of range value to be not stored, got %d", i.Int16)
+	}
+	if _, err := proto.Marshal(&i); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	var j nilt.Int8
+	if err := protojson.Unmarshal([]byte(`{"value":300}`), &j); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if j.Int8 != 0 {
+		t.Errorf("expected out of range value to be not stored, got %d", j.Int8)
+	}
+	if err := protojson.Unmarshal([]byte(`{"value":-1}`), &j); err != nil || j.Int8 != -1 {
+		t.Errorf("expected valid value to be stored, got %v and %d", err, j.Int8)
+	}
+}
+
+func TestProto_nil(t *testing.T) {
+	for _, e := range examples {
+		if _, ok := e.(proto.Message); !ok {
+			continue
+		}
+		m := reflect.Zero(reflect.TypeOf(e)).Interface().(proto.Message)
+
+		if b, err := proto.Marshal(m); err != nil || len(b) != 0 {
+			t.Errorf("%T: expected no output, got %v and %v", m, b, err)
+		}
+		if n := proto.Size(m); n != 0 {
+			t.Errorf("%T: expected zero size, got %d", m, n)
+		}
+		if b, err := protojson.Marshal(m); err != nil || string(b) != "{}" {
+			t.Errorf("%T: expected empty object, got %s and %v", m, b, err)
+		}
+		if s, ok := m.(fmt.Stringer); ok && s.String() != "" {
+			t.Errorf("%T: expected empty string, got %q", m, s.String())
+		}
+		if m.ProtoReflect().IsValid() {
+			t.Errorf("%T: expected message of nil pointer to be invalid", m)
+		}
+	}
+}
+
+func TestProto_Clone(t *testing.T) {
+	for _, e := range examples {
+		m, ok := e.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		got := proto.Clone(m)
+		if reflect.TypeOf(got) != reflect.TypeOf(m) {
+			t.Errorf("%T: wrong type of the clone %T", e, got)
+			continue
+		}
+		if !proto.Equal(got, m) {
+			t.Errorf("%T: expected %v but got %v", e, m, got)
+		}
+	}
+}
+
+func TestProto_Merge(t *testing.T) {
+	given := time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC)
+	got := &nilt.Time{}
+	proto.Merge(got, &nilt.Time{Time: given, Valid: true})
+	if !got.Valid || !got.Time.Equal(given) {
+		t.Errorf("wrong time, expected %s but got %#v", given, got)
+	}
+
+	d := &nilt.Duration{Duration: time.Hour}
+	proto.Merge(d, &nilt.Duration{Valid: true})
+	if d.Duration != time.Hour || !d.Valid {
+		t.Errorf("wrong duration, expected valid %s but got %#v", time.Hour, d)
+	}
+}
+
+func TestProto_Unmarshal_merge(t *testing.T) {
+	valid, err := proto.Marshal(&nilt.Bool{Valid: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := map[string]struct {
+		given, expected proto.Message
+	}{
+		"int8":     {given: &nilt.Int8{Int8: -5}, expected: &nilt.Int8{Int8: -5, Valid: true}},
+		"int64":    {given: &nilt.Int64{Int64: 5}, expected: &nilt.Int64{Int64: 5, Valid: true}},
+		"uint":     {given: &nilt.Uint{Uint: 5}, expected: &nilt.Uint{Uint: 5, Valid: true}},
+		"uint16":   {given: &nilt.Uint16{Uint16: 5}, expected: &nilt.Uint16{Uint16: 5, Valid: true}},
+		"time":     {given: &nilt.Time{Time: time.Unix(5, 1).UTC()}, expected: &nilt.Time{Time: time.Unix(5, 1).UTC(), Valid: true}},
+		"duration": {given: &nilt.Duration{Duration: time.Hour}, expected: &nilt.Duration{Duration: time.Hour, Valid: true}},
+	}
+
+	for d, c := range cases {
+		if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(valid, c.given); err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(c.given, c.expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, c.given)
+		}
+	}
+
+	given := &nilt.Duration{Duration: time.Hour, Valid: true}
+	b, err := proto.Marshal(&nilt.Duration{Duration: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err = (proto.UnmarshalOptions{Merge: true}).Unmarshal(b, given); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if given.Duration != time.Minute || !given.Valid {
+		t.Errorf("wrong output, expected valid %s but got %#v", time.Minute, given)
+	}
+}
+
+func TestProto_ProtoReflect(t *testing.T) {
+	cases := map[string]struct {
+		given    proto.Message
+		expected protoreflect.FullName
+	}{
+		"string":   {given: &nilt.String{}, expected: "nilt.String"},
+		"uint8":    {given: &nilt.Uint8{}, expected: "nilt.Uint8"},
+		"time":     {given: &nilt.Time{}, expected: "nilt.Time"},
+		"duration": {given: &nilt.Duration{}, expected: "nilt.Duration"},
+	}
+
+	for d, c := range cases {
+		m := c.given.ProtoReflect()
+		if got := m.Descriptor().FullName(); got != c.expected {
+			t.Errorf("%s: wrong descriptor, expected %s but got %s", d, c.expected, got)
+		}
+		if m.Interface() != c.given {
+			t.Errorf("%s: expected message to wrap the value", d)
+		}
+		if !m.IsValid() {
+			t.Errorf("%s: expected message to be valid", d)
+		}
+
+		valid := m.Descriptor().Fields().ByName("valid")
+		m.Set(valid, protoreflect.ValueOfBool(true))
+		if !m.Has(valid) || !m.Interface().(interface{ Appear() bool }).Appear() {
+			t.Errorf("%s: expected value to be valid after set", d)
+		}
+	}
+
+	var i8 nilt.Int8
+	m := i8.ProtoReflect()
+	m.Set(m.Descriptor().Fields().ByName("value"), protoreflect.ValueOfInt32(-5))
+	if i8.Int8 != -5 {
+		t.Errorf("wrong value, expected -5 but got %d", i8.Int8)
+	}
+	if (*nilt.Int8)(nil).ProtoReflect().IsValid() {
+		t.Error("expected message of nil pointer to be invalid")
+	}
+}
+
+// TestProto_wireCompatibility decodes values encoded by every type using a decoder driven by the descriptor of nilt.proto,
+// and the other way around.
+func TestProto_wireCompatibility(t *testing.T) {
+	for _, e := range examples {
+		m, ok := e.(proto.Message)
+		if !ok {
+			continue
+		}
+		desc := niltpb.File_nilt_proto.Messages().ByName(protoreflect.Name(reflect.TypeOf(e).Elem().Name()))
+		if desc == nil {
+			t.Errorf("%T: missing message in nilt.proto", e)
+			continue
+		}
+
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+		dm := dynamicpb.NewMessage(desc)
+		if err = proto.Unmarshal(b, dm); err != nil {
+			t.Errorf("%T: unexpected error for %v: %s", e, b, err.Error())
+			continue
+		}
+		if len(dm.GetUnknown()) > 0 {
+			t.Errorf("%T: unexpected unknown fields in %v", e, b)
+		}
+		if !proto.Equal(dm, m) {
+			t.Errorf("%T: wrong output for %v, expected %v but got %v", e, b, m, dm)
+		}
+
+		if b, err = proto.Marshal(dm); err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+		got := newLike(e).(proto.Message)
+		if err = proto.Unmarshal(b, got); err != nil {
+			t.Errorf("%T: unexpected error for %v: %s", e, b, err.Error())
+			continue
+		}
+		if !proto.Equal(got, m) {
+			t.Errorf("%T: wrong output for %v, expected %v but got %v", e, b, m, got)
+		}
+	}
+}
+
+// TestProto_structTags checks struct tags used by reflection based libraries, e.g. gogo/protobuf, against nilt.proto.
+// Every field of every message type has to be tagged.
+func TestProto_structTags(t *testing.T) {
+	wireTypes := map[protoreflect.Kind]string{
+		protoreflect.BoolKind:    "varint",
+		protoreflect.Int32Kind:   "varint",
+		protoreflect.Int64Kind:   "varint",
+		protoreflect.Uint32Kind:  "varint",
+		protoreflect.Uint64Kind:  "varint",
+		protoreflect.FloatKind:   "fixed32",
+		protoreflect.DoubleKind:  "fixed64",
+		protoreflect.StringKind:  "bytes",
+		protoreflect.BytesKind:   "bytes",
+		protoreflect.MessageKind: "bytes",
+	}
+
+	seen := make(map[reflect.Type]bool)
+	for _, e := range examples {
+		typ := reflect.TypeOf(e).Elem()
+		if _, ok := e.(proto.Message); !ok || seen[typ] {
+			continue
+		}
+		seen[typ] = true
+
+		desc := niltpb.File_nilt_proto.Messages().ByName(protoreflect.Name(typ.Name()))
+		if desc == nil {
+			t.Errorf("%s: message not found in nilt.proto", typ)
+			continue
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			tag, ok := typ.Field(i).Tag.Lookup("protobuf")
+			if !ok {
+				t.Errorf("%s.%s: missing protobuf tag", typ, typ.Field(i).Name)
+				continue
+			}
+			var wire, num, name string
+			if parts := strings.Split(tag, ","); len(parts) >= 4 {
+				wire, num, name = parts[0], parts[1], strings.TrimPrefix(parts[3], "name=")
+			}
+
+			fd := desc.Fields().ByName(protoreflect.Name(name))
+			if fd == nil {
+				t.Errorf("%s.%s: field %q not found in %s", typ, typ.Field(i).Name, name, desc.FullName())
+				continue
+			}
+			if expected := wireTypes[fd.Kind()]; wire != expected {
+				t.Errorf("%s.%s: wrong wire type, expected %s but got %s", typ, typ.Field(i).Name, expected, wire)
+			}
+			if expected := strconv.Itoa(int(fd.Number())); num != expected {
+				t.Errorf("%s.%s: wrong field number, expected %s but got %s", typ, typ.Field(i).Name, expected, num)
+			}
+		}
+	}
+}
+
+func TestProto_wrappers(t *testing.T) {
+	ts := time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC)
+	cases := map[string]struct {
+		got, expected interface{}
+	}{
+		"string":           {got: nilt.StringFromProto(wrapperspb.String("text")), expected: nilt.String{String: "text", Valid: true}},
+		"string nil":       {got: nilt.StringFromProto(nil), expected: nilt.String{}},
+		"int32":            {got: nilt.Int32FromProto(wrapperspb.Int32(-1)), expected: nilt.Int32{Int32: -1, Valid: true}},
+		"int64 zero":       {got: nilt.Int64FromProto(wrapperspb.Int64(0)), expected: nilt.Int64{Valid: true}},
+		"uint32":           {got: nilt.Uint32FromProto(wrapperspb.UInt32(1)), expected: nilt.Uint32{Uint32: 1, Valid: true}},
+		"uint64 nil":       {got: nilt.Uint64FromProto(nil), expected: nilt.Uint64{}},
+		"float32":          {got: nilt.Float32FromProto(wrapperspb.Float(1.5)), expected: nilt.Float32{Float32: 1.5, Valid: true}},
+		"float64":          {got: nilt.Float64FromProto(wrapperspb.Double(-2.5)), expected: nilt.Float64{Float64: -2.5, Valid: true}},
+		"bool":             {got: nilt.BoolFromProto(wrapperspb.Bool(false)), expected: nilt.Bool{Valid: true}},
+		"bytes":            {got: nilt.BytesFromProto(wrapperspb.Bytes([]byte{1})), expected: nilt.Bytes{Bytes: []byte{1}, Valid: true}},
+		"time":             {got: nilt.TimeFromProto(timestamppb.New(ts)), expected: nilt.Time{Time: ts, Valid: true}},
+		"time nil":         {got: nilt.TimeFromProto(nil), expected: nilt.Time{}},
+		"duration":         {got: nilt.DurationFromProto(durationpb.New(time.Minute)), expected: nilt.Duration{Duration: time.Minute, Valid: true}},
+		"duration nil":     {got: nilt.DurationFromProto(nil), expected: nilt.Duration{}},
+		"to string":        {got: (&nilt.String{String: "text", Valid: true}).ToProto().GetValue(), expected: "text"},
+		"to int64":         {got: (&nilt.Int64{Int64: 0, Valid: true}).ToProto() != nil, expected: true},
+		"to int64 invalid": {got: (&nilt.Int64{Int64: 1}).ToProto(), expected: (*wrapperspb.Int64Value)(nil)},
+		"to bool nil":      {got: (*nilt.Bool)(nil).ToProto(), expected: (*wrapperspb.BoolValue)(nil)},
+		"to uint64":        {got: (&nilt.Uint64{Uint64: math.MaxUint64, Valid: true}).ToProto().GetValue(), expected: uint64(math.MaxUint64)},
+		"to bytes":         {got: (&nilt.Bytes{Bytes: []byte{}, Valid: true}).ToProto().GetValue(), expected: []byte{}},
+		"to time":          {got: (&nilt.Time{Time: ts, Valid: true}).ToProto().AsTime(), expected: ts},
+		"to time invalid":  {got: (&nilt.Time{Time: ts}).ToProto(), expected: (*timestamppb.Timestamp)(nil)},
+		"to duration":      {got: (&nilt.Duration{Duration: -time.Second, Valid: true}).ToProto().AsDuration(), expected: -time.Second},
+	}
+
+	for d, c := range cases {
+		if !reflect.DeepEqual(c.got, c.expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, c.got)
+		}
+	}
+}
+
+func TestPtr(t *testing.T) {
+	s, i8, ts := "text", int8(-1), time.Date(2016, 4, 24, 12, 30, 15, 0, time.UTC)
+	cases := map[string]struct {
+		got, expected interface{}
+	}{
+		"string":         {got: nilt.StringFromPtr(&s), expected: nilt.String{String: "text", Valid: true}},
+		"string nil":     {got: nilt.StringFromPtr(nil), expected: nilt.String{}},
+		"int8":           {got: nilt.Int8FromPtr(&i8), expected: nilt.Int8{Int8: -1, Valid: true}},
+		"time":           {got: nilt.TimeFromPtr(&ts), expected: nilt.Time{Time: ts, Valid: true}},
+		"of":             {got: nilt.FromPtr(&s), expected: nilt.Of[string]{V: "text", Valid: true}},
+		"of nil":         {got: nilt.FromPtr[int64](nil), expected: nilt.Of[int64]{}},
+		"to string":      {got: *(&nilt.String{String: "text", Valid: true}).Ptr(), expected: "text"},
+		"to zero":        {got: *(&nilt.Uint{Valid: true}).Ptr(), expected: uint(0)},
+		"to invalid":     {got: (&nilt.Uint{Uint: 1}).Ptr(), expected: (*uint)(nil)},
+		"to nil":         {got: (*nilt.Duration)(nil).Ptr(), expected: (*time.Duration)(nil)},
+		"to of":          {got: *(&nilt.Of[bool]{V: true, Valid: true}).Ptr(), expected: true},
+		"slice":          {got: nilt.Int8FromPtrs([]*int8{&i8, nil}), expected: []nilt.Int8{{Int8: -1, Valid: true}, {}}},
+		"slice nil":      {got: nilt.StringFromPtrs(nil), expected: []nilt.String(nil)},
+		"slice of":       {got: nilt.FromPtrs([]*string{nil, &s}), expected: []nilt.Of[string]{{}, {V: "text", Valid: true}}},
+		"to slice":       {got: nilt.StringPtrs([]nilt.String{{String: "text", Valid: true}, {}}), expected: []*string{&s, nil}},
+		"to slice nil":   {got: nilt.BoolPtrs(nil), expected: []*bool(nil)},
+		"to slice of":    {got: nilt.Ptrs([]nilt.Of[int8]{{}, {V: -1, Valid: true}}), expected: []*int8{nil, &i8}},
+		"to slice empty": {got: nilt.BytesPtrs([]nilt.Bytes{}), expected: []*[]byte{}},
+	}
+
+	for d, c := range cases {
+		if !reflect.DeepEqual(c.got, c.expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", d, c.expected, c.got)
+		}
+	}
+
+	given := nilt.Int64{Int64: 1, Valid: true}
+	*given.Ptr() = 2
+	if given.Int64 != 1 {
+		t.Errorf("expected pointer to a copy, but value changed to %d", given.Int64)
+	}
+}
+
+func TestProto_String(t *testing.T) {
+	cases := map[string]struct {
+		given    fmt.Stringer
+		expected string
+	}{
+		"zero":    {given: &nilt.Int64{}, expected: ""},
+		"int64":   {given: &nilt.Int64{Int64: -1, Valid: true}, expected: "value:-1 valid:true "},
+		"uint64":  {given: &nilt.Uint64{Uint64: math.MaxUint64}, expected: "value:18446744073709551615 "},
+		"float32": {given: &nilt.Float32{Float32: 1.1, Valid: true}, expected: "value:1.1 valid:true "},
+		"nan":     {given: &nilt.Float64{Float64: math.NaN(), Valid: true}, expected: "value:nan valid:true "},
+		"bool":    {given: &nilt.Bool{Bool: true}, expected: "value:true "},
+		"bytes":   {given: &nilt.Bytes{Bytes: []byte("a\"\n\x00"), Valid: true}, expected: `value:"a\"\n\000" valid:true `},
+		"int":     {given: &nilt.Int{Int: -1, Valid: true}, expected: "value:-1 valid:true "},
+	}
+
+	for d, c := range cases {
+		if got := c.given.String(); got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", d, c.expected, got)
+		}
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	for _, e := range examples {
+		got, valid, ok := nilt.Unwrap(e)
+		if !ok {
+			t.Errorf("%T: expected to be unwrapped", e)
+			continue
+		}
+		if valid != e.Appear() {
+			t.Errorf("%T: wrong validity, expected %t but got %t", e, e.Appear(), valid)
+		}
+		if expected := reflect.ValueOf(e).Elem().Field(0).Interface(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%T: wrong value, expected %#v but got %#v", e, expected, got)
+		}
+	}
+
+	if got, valid, ok := nilt.Unwrap((*nilt.Int8)(nil)); !ok || valid || got != int8(0) {
+		t.Errorf("nil pointer: expected invalid int8 zero, got %#v, %t and %t", got, valid, ok)
+	}
+	if _, _, ok := nilt.Unwrap(nilt.Int8{}); ok {
+		t.Error("expected value that is not a pointer to be not unwrapped")
+	}
+}
+
+// nullable is implemented by every type of the package.
+type nullable interface {
+	json.Marshaler
+	json.Unmarshaler
+	AppendJSON([]byte) ([]byte, error)
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	encoding.BinaryMarshaler
+	encoding.BinaryAppender
+	encoding.BinaryUnmarshaler
+	xml.Marshaler
+	xml.Unmarshaler
+	xml.MarshalerAttr
+	xml.UnmarshalerAttr
+	gob.GobEncoder
+	gob.GobDecoder
+	driver.Valuer
+	sql.Scanner
+	Appear() bool
+	IsZero() bool
+}
+
+// examples lists valid and invalid values of every type of the package.
+// Each new type has to be added here to be covered by the shared test suites.
+var examples = []nullable{
+	&nilt.String{String: "text", Valid: true},
+	&nilt.String{String: "", Valid: true},
+	&nilt.String{},
+	&nilt.Int{Int: -123, Valid: true},
+	&nilt.Int{Int: 0, Valid: true},
+	&nilt.Int{},
+	&nilt.Int8{Int8: -128, Valid: true},
+	&nilt.Int8{Int8: 0, Valid: true},
+	&nilt.Int8{},
+	&nilt.Int16{Int16: 32767, Valid: true},
+	&nilt.Int16{Int16: 0, Valid: true},
+	&nilt.Int16{},
+	&nilt.Int32{Int32: -2147483648, Valid: true},
+	&nilt.Int32{Int32: 0, Valid: true},
+	&nilt.Int32{},
+	&nilt.Int64{Int64: 9223372036854775807, Valid: true},
+	&nilt.Int64{Int64: 0, Valid: true},
+	&nilt.Int64{},
+	&nilt.Uint{Uint: 123, Valid: true},
+	&nilt.Uint{Uint: 0, Valid: true},
+	&nilt.Uint{},
+	&nilt.Uint8{Uint8: 255, Valid: true},
+	&nilt.Uint8{Uint8: 0, Valid: true},
+	&nilt.Uint8{},
+	&nilt.Uint16{Uint16: 65535, Valid: true},
+	&nilt.Uint16{Uint16: 0, Valid: true},
+	&nilt.Uint16{},
+	&nilt.Uint32{Uint32: 4294967295, Valid: true},
+	&nilt.Uint32{Uint32: 0, Valid: true},
+	&nilt.Uint32{},
+	&nilt.Uint64{Uint64: 18446744073709551615, Valid: true},
+	&nilt.Uint64{Uint64: 0, Valid: true},
+	&nilt.Uint64{},
+	&nilt.Float32{Float32: 1.25, Valid: true},
+	&nilt.Float32{Float32: 0, Valid: true},
+	&nilt.Float32{},
+	&nilt.Float64{Float64: -1e100, Valid: true},
+	&nilt.Float64{Float64: 0, Valid: true},
+	&nilt.Float64{},
+	&nilt.Bool{Bool: true, Valid: true},
+	&nilt.Bool{Bool: false, Valid: true},
+	&nilt.Bool{},
+	&nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 123456789, time.UTC), Valid: true},
+	&nilt.Time{Time: time.Time{}, Valid: true},
+	&nilt.Time{},
+	&nilt.Duration{Duration: -90 * time.Minute, Valid: true},
+	&nilt.Duration{Duration: 0, Valid: true},
+	&nilt.Duration{},
+	&nilt.Bytes{Bytes: []byte{0, 1, 255}, Valid: true},
+	&nilt.Bytes{Bytes: []byte{}, Valid: true},
+	&nilt.Bytes{},
+	&nilt.Of[string]{V: "text", Valid: true},
+	&nilt.Of[string]{},
+	&nilt.Of[int64]{V: -1, Valid: true},
+	&nilt.Of[int64]{},
+}
+
+// newLike returns a pointer to zero value of the same type as given example.
+func newLike(n nullable) nullable {
+	return reflect.New(reflect.TypeOf(n).Elem()).Interface().(nullable)
+}
+
+func TestJSON_null(t *testing.T) {
+	for _, e := range examples {
+		if !e.Appear() {
+			continue
+		}
+
+		got := newLike(e)
+		b, err := e.MarshalJSON()
+		if err != nil {
+			t.Fatalf("%T: unexpected error: %s", e, err.Error())
+		}
+		if err = json.Unmarshal(b, got); err != nil {
+			t.Fatalf("%T: unexpected error: %s", e, err.Error())
+		}
+
+		if err = json.Unmarshal([]byte("null"), got); err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+		if got.Appear() {
+			t.Errorf("%T: expected to be invalid after unmarshaling null", e)
+		}
+		if !reflect.DeepEqual(got, newLike(e)) {
+			t.Errorf("%T: expected zero value after unmarshaling null, got %#v", e, got)
+		}
+	}
+}
+
+func TestJSON_nilPointer(t *testing.T) {
+	var given *nilt.Int64
+	b, err := json.Marshal(struct{ V *nilt.Int64 }{V: given})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(b) != `{"V":null}` {
+		t.Errorf("wrong output, got %s", string(b))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MarshalJSON called directly through nil pointer to panic")
+		}
+	}()
+	_, _ = given.MarshalJSON()
+}
+
+func TestJSON_symmetry(t *testing.T) {
+	testJSONSymmetry(t)
+}
+
+func TestJSON_objectSymmetry(t *testing.T) {
+	nilt.JSONObject = true
+	defer func() { nilt.JSONObject = false }()
+
+	testJSONSymmetry(t)
+}
+
+func testJSONSymmetry(t *testing.T) {
+	t.Helper()
+
+	for _, e := range examples {
+		b, err := json.Marshal(e)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e)
+		if err = json.Unmarshal(b, got); err != nil {
+			t.Errorf("%T: unexpected error for %s: %s", e, b, err.Error())
+			continue
+		}
+
+		if !e.Appear() {
+			if !reflect.DeepEqual(got, newLike(e)) {
+				t.Errorf("%T: expected zero value after unmarshaling %s, got %#v", e, b, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %s, expected %#v but got %#v", e, b, e, got)
+		}
+	}
+}
+
+func TestJSON_within(t *testing.T) {
+	type within struct {
+		ID    nilt.Int64   `json:"id"`
+		Name  *nilt.String `json:"name"`
+		Admin nilt.Bool    `json:"admin"`
+	}
+
+	var w within
+	if err := json.Unmarshal([]byte(`{"id":null,"name":null,"admin":null}`), &w); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if w.ID.Valid || w.Name != nil || w.Admin.Valid {
+		t.Errorf("wrong output, expected all fields to be invalid but got %#v", w)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":1,"name":"text","admin":false}`), &w); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !w.ID.Valid || w.Name == nil || !w.Name.Valid || !w.Admin.Valid {
+		t.Errorf("wrong output, expected all fields to be valid but got %#v", w)
+	}
+}
+
+func TestJSON_object(t *testing.T) {
+	cases := map[string]struct {
+		given    json.Marshaler
+		expected string
+	}{
+		"invalid":        {given: nilt.Int64{Int64: 1}, expected: `{}`},
+		"int64":          {given: nilt.Int64{Int64: -1, Valid: true}, expected: `{"value":-1,"valid":true}`},
+		"zero int64":     {given: nilt.Int64{Valid: true}, expected: `{"valid":true}`},
+		"empty string":   {given: nilt.String{Valid: true}, expected: `{"valid":true}`},
+		"empty bytes":    {given: nilt.Bytes{Bytes: []byte{}, Valid: true}, expected: `{"valid":true}`},
+		"zero time":      {given: nilt.Time{Valid: true}, expected: `{"value":"0001-01-01T00:00:00Z","valid":true}`},
+		"duration":       {given: nilt.Duration{Duration: time.Second, Valid: true}, expected: `{"value":"1s","valid":true}`},
+		"generic string": {given: nilt.Of[string]{V: "text", Valid: true}, expected: `{"value":"text","valid":true}`},
+	}
+
+	nilt.JSONObject = true
+	defer func() { nilt.JSONObject = false }()
+
+	for d, c := range cases {
+		b, err := json.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if string(b) != c.expected {
+			t.Errorf("%s: wrong output, expected %s but got %s", d, c.expected, string(b))
+		}
+	}
+}
+
+func TestJSON_objectInput(t *testing.T) {
+	cases := map[string]struct {
+		given    nullable
+		expected nullable
+	}{
+		`{}`:                          {given: &nilt.Int64{Int64: 1, Valid: true}, expected: &nilt.Int64{}},
+		`{"valid":false}`:             {given: &nilt.Int64{Int64: 1, Valid: true}, expected: &nilt.Int64{}},
+		`{"value":5,"valid":false}`:   {given: &nilt.Int64{}, expected: &nilt.Int64{}},
+		`{"valid":true}`:              {given: &nilt.Int64{Int64: 1}, expected: &nilt.Int64{Valid: true}},
+		`{"value":null,"valid":true}`: {given: &nilt.String{}, expected: &nilt.String{Valid: true}},
+		`{"valid":true,"value":"1h"}`: {given: &nilt.Duration{}, expected: &nilt.Duration{Duration: time.Hour, Valid: true}},
+		`{"value":"x","valid":true}`:  {given: &nilt.String{}, expected: &nilt.String{String: "x", Valid: true}},
+	}
+
+	for given, c := range cases {
+		if err := json.Unmarshal([]byte(given), c.given); err != nil {
+			t.Errorf("%s: unexpected error: %s", given, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(c.given, c.expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", given, c.expected, c.given)
+		}
+	}
+
+	for _, given := range []string{`{"value":"x","valid":true}`, `{"value":1,"valid":"true"}`} {
+		got := nilt.Int64{Int64: 1, Valid: true}
+		if err := json.Unmarshal([]byte(given), &got); err == nil {
+			t.Errorf("%s: expected error", given)
+		}
+		if got.Valid {
+			t.Errorf("%s: expected to be invalid after failed unmarshal", given)
+		}
+	}
+}
+
+func TestJSON_omitzero(t *testing.T) {
+	type within struct {
+		ID    nilt.Int64   `json:"id,omitzero"`
+		Name  nilt.String  `json:"name,omitzero"`
+		Score nilt.Float64 `json:"score"`
+	}
+
+	b, err := json.Marshal(within{Name: nilt.String{Valid: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := `{"name":"","score":null}`; string(b) != expected {
+		t.Errorf("wrong output, expected %s but got %s", expected, string(b))
+	}
+}
+
+func TestJSON_AppendJSON(t *testing.T) {
+	for _, object := range []bool{false, true} {
+		nilt.JSONObject = object
+
+		for _, e := range examples {
+			expected, err := e.MarshalJSON()
+			if err != nil {
+				t.Errorf("%T: unexpected error: %s", e, err.Error())
+				continue
+			}
+			got, err := e.AppendJSON([]byte("prefix"))
+			if err != nil {
+				t.Errorf("%T: unexpected error: %s", e, err.Error())
+				continue
+			}
+			if string(got) != "prefix"+string(expected) {
+				t.Errorf("%T: wrong output, expected prefix%s but got %s", e, expected, got)
+			}
+		}
+	}
+	nilt.JSONObject = false
+}
+
+func TestJSON_string(t *testing.T) {
+	for _, given := range []string{
+		"", "text", `"quoted"`, `back\slash`, "<a href='x'>&amp;</a>", "\b\f\n\r\t\x00\x1f\x7f",
+		"zażółć gęślą jaźń", "\u2028\u2029", "invalid \xff\xfe utf-8", "emoji 😀",
+	} {
+		testJSONString(t, given)
+	}
+}
+
+// FuzzJSON_string checks that strings are escaped the same as by encoding/json.
+func FuzzJSON_string(f *testing.F) {
+	f.Add("<text>\n\u2028\xff")
+
+	f.Fuzz(testJSONString)
+}
+
+func testJSONString(t *testing.T, given string) {
+	expected, err := json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	got, err := nilt.String{String: given, Valid: true}.MarshalJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("%q: wrong output, expected %s but got %s", given, expected, got)
+	}
+}
+
+func TestJSON_AppendJSON_allocs(t *testing.T) {
+	cases := map[string]interface {
+		AppendJSON([]byte) ([]byte, error)
+	}{
+		"int64":   nilt.Int64{Int64: -123456789, Valid: true},
+		"uint8":   nilt.Uint8{Uint8: 255, Valid: true},
+		"uint64":  nilt.Uint64{Uint64: 18446744073709551615, Valid: true},
+		"float32": nilt.Float32{Float32: 1.25, Valid: true},
+		"float64": nilt.Float64{Float64: 1e-7, Valid: true},
+		"bool":    nilt.Bool{Bool: true, Valid: true},
+		"invalid": nilt.Int64{},
+		"string":  nilt.String{String: "<text>", Valid: true},
+		"generic": nilt.Of[int32]{V: 1, Valid: true},
+	}
+
+	buf := make([]byte, 0, 64)
+	for d, c := range cases {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := c.AppendJSON(buf[:0]); err != nil {
+				t.Fatalf("%s: unexpected error: %s", d, err.Error())
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", d, allocs)
+		}
+	}
+}
+
+func BenchmarkInt64_AppendJSON(b *testing.B) {
+	benchmarkAppendJSON(b, nilt.Int64{Int64: -123456789, Valid: true})
+}
+
+func BenchmarkUint64_AppendJSON(b *testing.B) {
+	benchmarkAppendJSON(b, nilt.Uint64{Uint64: 123456789, Valid: true})
+}
+
+func BenchmarkFloat64_AppendJSON(b *testing.B) {
+	benchmarkAppendJSON(b, nilt.Float64{Float64: 1.5, Valid: true})
+}
+
+func BenchmarkBool_AppendJSON(b *testing.B) {
+	benchmarkAppendJSON(b, nilt.Bool{Bool: true, Valid: true})
+}
+
+func BenchmarkString_AppendJSON(b *testing.B) {
+	benchmarkAppendJSON(b, nilt.String{String: "text", Valid: true})
+}
+
+func benchmarkAppendJSON(b *testing.B, given interface {
+	AppendJSON([]byte) ([]byte, error)
+}) {
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := given.AppendJSON(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInt64_MarshalJSON(b *testing.B) {
+	given := nilt.Int64{Int64: -123456789, Valid: true}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := given.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestLenient_UnmarshalJSON(t *testing.T) {
+	type record struct {
+		ID      nilt.Lenient[int64]         `json:"id"`
+		Small   nilt.Lenient[uint8]         `json:"small"`
+		Name    nilt.Lenient[string]        `json:"name"`
+		Active  nilt.Lenient[bool]          `json:"active"`
+		Score   nilt.Lenient[float64]       `json:"score"`
+		Created nilt.Lenient[time.Time]     `json:"created"`
+		Timeout nilt.Lenient[time.Duration] `json:"timeout"`
+	}
+
+	cases := map[string]record{
+		`{"id":"42","small":"255","name":42,"active":"true","score":"-1.5","created":"2016-04-24T00:00:00Z","timeout":"60"}`: {
+			ID:      nilt.Lenient[int64]{nilt.Of[int64]{V: 42, Valid: true}},
+			Small:   nilt.Lenient[uint8]{nilt.Of[uint8]{V: 255, Valid: true}},
+			Name:    nilt.Lenient[string]{nilt.Of[string]{V: "42", Valid: true}},
+			Active:  nilt.Lenient[bool]{nilt.Of[bool]{V: true, Valid: true}},
+			Score:   nilt.Lenient[float64]{nilt.Of[float64]{V: -1.5, Valid: true}},
+			Created: nilt.Lenient[time.Time]{nilt.Of[time.Time]{V: time.Date(2016, 4, 24, 0, 0, 0, 0, time.UTC), Valid: true}},
+			Timeout: nilt.Lenient[time.Duration]{nilt.Of[time.Duration]{V: 60, Valid: true}},
+		},
+		`{"id":42,"small":1,"name":"","active":false,"score":1e3,"created":null,"timeout":"1m"}`: {
+			ID:      nilt.Lenient[int64]{nilt.Of[int64]{V: 42, Valid: true}},
+			Small:   nilt.Lenient[uint8]{nilt.Of[uint8]{V: 1, Valid: true}},
+			Name:    nilt.Lenient[string]{nilt.Of[string]{V: "", Valid: true}},
+			Active:  nilt.Lenient[bool]{nilt.Of[bool]{V: false, Valid: true}},
+			Score:   nilt.Lenient[float64]{nilt.Of[float64]{V: 1000, Valid: true}},
+			Timeout: nilt.Lenient[time.Duration]{nilt.Of[time.Duration]{V: time.Minute, Valid: true}},
+		},
+		`{"id":"","small":"","name":null,"active":"","score":"","created":"","timeout":""}`: {},
+	}
+
+	for given, expected := range cases {
+		got := record{ID: nilt.Lenient[int64]{nilt.Of[int64]{V: 1, Valid: true}}}
+		if err := json.Unmarshal([]byte(given), &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", given, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: wrong output, expected %#v but got %#v", given, expected, got)
+		}
+	}
+}
+
+func TestLenient_UnmarshalJSON_invalidInput(t *testing.T) {
+	cases := map[string]json.Unmarshaler{
+		`"text"`:  &nilt.Lenient[int64]{nilt.Of[int64]{V: 1, Valid: true}},
+		`"256"`:   &nilt.Lenient[uint8]{nilt.Of[uint8]{V: 1, Valid: true}},
+		`"-1"`:    &nilt.Lenient[uint32]{nilt.Of[uint32]{V: 1, Valid: true}},
+		`"yes"`:   &nilt.Lenient[bool]{nilt.Of[bool]{V: true, Valid: true}},
+		`"today"`: &nilt.Lenient[time.Time]{nilt.Of[time.Time]{V: time.Now(), Valid: true}},
+		`true`:    &nilt.Lenient[string]{nilt.Of[string]{V: "text", Valid: true}},
+		`"NaN"`:   &nilt.Lenient[float64]{nilt.Of[float64]{V: 1, Valid: true}},
+		`"Inf"`:   &nilt.Lenient[float64]{nilt.Of[float64]{V: 1, Valid: true}},
+		`"+Inf"`:  &nilt.Lenient[float64]{nilt.Of[float64]{V: 1, Valid: true}},
+		`"-Inf"`:  &nilt.Lenient[float32]{nilt.Of[float32]{V: 1, Valid: true}},
+	}
+
+	for given, u := range cases {
+		if err := json.Unmarshal([]byte(given), u); err == nil {
+			t.Errorf("%T: expected error for %s", u, given)
+		}
+		if u.(interface{ Appear() bool }).Appear() {
+			t.Errorf("%T: expected to be invalid after failed unmarshal of %s", u, given)
+		}
+	}
+}
+
+func TestLenient_strictByDefault(t *testing.T) {
+	for _, given := range []string{`"42"`, `""`} {
+		var got nilt.Int64
+		if err := json.Unmarshal([]byte(given), &got); err == nil {
+			t.Errorf("%s: expected error", given)
+		}
+	}
+
+	var s nilt.String
+	if err := json.Unmarshal([]byte(`42`), &s); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestJSON_invalidInput(t *testing.T) {
+	cases := map[string]nullable{
+		`"text"`: &nilt.Int64{Int64: 1, Valid: true},
+		`1`:      &nilt.String{String: "text", Valid: true},
+		`1.5`:    &nilt.Int32{Int32: 1, Valid: true},
+		`-1`:     &nilt.Uint8{Uint8: 1, Valid: true},
+		`"1d"`:   &nilt.Duration{Duration: 1, Valid: true},
+	}
+
+	for given, n := range cases {
+		if err := json.Unmarshal([]byte(given), n); err == nil {
+			t.Errorf("%T: expected error for %s", n, given)
+		}
+		if n.Appear() {
+			t.Errorf("%T: expected to be invalid after failed unmarshal of %s", n, given)
+		}
+	}
+}
+
+func TestText_symmetry(t *testing.T) {
+	for _, e := range examples {
+		b, err := e.MarshalText()
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e)
+		if err = got.UnmarshalText(b); err != nil {
+			t.Errorf("%T: unexpected error for %q: %s", e, b, err.Error())
+			continue
+		}
+
+		if !e.Appear() || len(b) == 0 {
+			if !reflect.DeepEqual(got, newLike(e)) {
+				t.Errorf("%T: expected zero value after unmarshaling %q, got %#v", e, b, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %q, expected %#v but got %#v", e, b, e, got)
+		}
+	}
+}
+
+func TestText_MarshalText(t *testing.T) {
+	cases := map[string]struct {
+		given    encoding.TextMarshaler
+		expected string
+	}{
+		"invalid":  {given: nilt.Int64{Int64: 1}, expected: ""},
+		"int64":    {given: nilt.Int64{Int64: -1, Valid: true}, expected: "-1"},
+		"float64":  {given: nilt.Float64{Float64: 1000000, Valid: true}, expected: "1000000"},
+		"float32":  {given: nilt.Float32{Float32: 1e-7, Valid: true}, expected: "1e-7"},
+		"bool":     {given: nilt.Bool{Valid: true}, expected: "false"},
+		"time":     {given: nilt.Time{Time: time.Date(2016, 4, 24, 0, 0, 0, 0, time.UTC), Valid: true}, expected: "2016-04-24T00:00:00Z"},
+		"duration": {given: nilt.Duration{Duration: time.Second, Valid: true}, expected: "1s"},
+		"bytes":    {given: nilt.Bytes{Bytes: []byte("text"), Valid: true}, expected: "dGV4dA=="},
+	}
+
+	for d, c := range cases {
+		b, err := c.given.MarshalText()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if string(b) != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", d, c.expected, string(b))
+		}
+	}
+}
+
+func TestText_mapKey(t *testing.T) {
+	given := map[nilt.Int64]string{
+		{Int64: 1, Valid: true}: "one",
+		{}:                      "none",
+	}
+
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(b) != `{"":"none","1":"one"}` {
+		t.Errorf("wrong output, got %s", string(b))
+	}
+
+	var got map[nilt.Int64]string
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("wrong output, expected %v but got %v", given, got)
+	}
+}
+
+func TestText_flag(t *testing.T) {
+	var d nilt.Duration
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.TextVar(&d, "timeout", &nilt.Duration{}, "")
+
+	if err := fs.Parse([]string{"-timeout", "1m"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !d.Valid || d.Duration != time.Minute {
+		t.Errorf("wrong output, got %#v", d)
+	}
+}
+
+func TestXML_symmetry(t *testing.T) {
+	for _, e := range examples {
+		var buf bytes.Buffer
+		if err := xml.NewEncoder(&buf).EncodeElement(e, xml.StartElement{Name: xml.Name{Local: "v"}}); err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		if !e.Appear() {
+			if buf.Len() != 0 {
+				t.Errorf("%T: expected invalid value to be omitted, got %s", e, buf.String())
+			}
+			continue
+		}
+
+		got := newLike(e)
+		if err := xml.Unmarshal(buf.Bytes(), got); err != nil {
+			t.Errorf("%T: unexpected error for %s: %s", e, buf.String(), err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %s, expected %#v but got %#v", e, buf.String(), e, got)
+		}
+	}
+}
+
+func TestXML_attrSymmetry(t *testing.T) {
+	for _, e := range examples {
+		attr, err := e.MarshalXMLAttr(xml.Name{Local: "v"})
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		if !e.Appear() {
+			if attr.Name.Local != "" {
+				t.Errorf("%T: expected invalid value to be omitted, got %#v", e, attr)
+			}
+			continue
+		}
+
+		got := newLike(e)
+		if err = got.UnmarshalXMLAttr(attr); err != nil {
+			t.Errorf("%T: unexpected error for %q: %s", e, attr.Value, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %q, expected %#v but got %#v", e, attr.Value, e, got)
+		}
+	}
+}
+
+func TestXML_within(t *testing.T) {
+	type within struct {
+		XMLName xml.Name     `xml:"user"`
+		ID      nilt.Int64   `xml:"id,attr"`
+		Name    nilt.String  `xml:"name"`
+		Age     *nilt.Uint8  `xml:"age"`
+		Score   nilt.Float64 `xml:"score"`
+	}
+
+	given := within{
+		ID:   nilt.Int64{Int64: 1, Valid: true},
+		Name: nilt.String{String: "<john>", Valid: true},
+		Age:  &nilt.Uint8{},
+	}
+
+	b, err := xml.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := `<user id="1"><name>&lt;john&gt;</name></user>`; string(b) != expected {
+		t.Errorf("wrong output, expected %s but got %s", expected, string(b))
+	}
+
+	nilt.XMLNil = true
+	defer func() { nilt.XMLNil = false }()
+
+	b, err = xml.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := `<user id="1"><name>&lt;john&gt;</name>` +
+		`<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>` +
+		`<score xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></score></user>`
+	if string(b) != expected {
+		t.Errorf("wrong output, expected %s but got %s", expected, string(b))
+	}
+
+	got := within{Score: nilt.Float64{Float64: 1, Valid: true}}
+	if err = xml.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got.ID != given.ID || got.Name != given.Name || got.Age == nil || got.Age.Valid || got.Score.Valid {
+		t.Errorf("wrong output, got %#v", got)
+	}
+}
+
+func TestBinary_symmetry(t *testing.T) {
+	for _, e := range examples {
+		b, err := e.MarshalBinary()
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e)
+		if err = got.UnmarshalBinary(b); err != nil {
+			t.Errorf("%T: unexpected error for %v: %s", e, b, err.Error())
+			continue
+		}
+
+		if !e.Appear() {
+			if !reflect.DeepEqual(got, newLike(e)) {
+				t.Errorf("%T: expected zero value after decoding %v, got %#v", e, b, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %v, expected %#v but got %#v", e, b, e, got)
+		}
+	}
+}
+
+func TestBinary_AppendBinary(t *testing.T) {
+	cases := map[string]struct {
+		given    encoding.BinaryAppender
+		expected []byte
+	}{
+		"invalid":  {given: nilt.Int64{Int64: 1}, expected: []byte{0xff, 0}},
+		"int8":     {given: nilt.Int8{Int8: -64, Valid: true}, expected: []byte{0xff, 1, 0x7f}},
+		"uint64":   {given: nilt.Uint64{Uint64: 300, Valid: true}, expected: []byte{0xff, 1, 0xac, 0x02}},
+		"float64":  {given: nilt.Float64{Float64: 2, Valid: true}, expected: []byte{0xff, 1, 0x40, 0, 0, 0, 0, 0, 0, 0}},
+		"duration": {given: nilt.Duration{Duration: 1, Valid: true}, expected: []byte{0xff, 1, 2}},
+		"bytes":    {given: nilt.Bytes{Bytes: []byte{}, Valid: true}, expected: []byte{0xff, 1}},
+	}
+
+	for d, c := range cases {
+		b, err := c.given.AppendBinary([]byte{0xff})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !bytes.Equal(b, c.expected) {
+			t.Errorf("%s: wrong output, expected %v but got %v", d, c.expected, b)
+		}
+	}
+}
+
+// FuzzBinary_roundTrip checks that valid and invalid values survive encoding and decoding.
+// Encoded forms are compared, so NaN floats are covered as well.
+func FuzzBinary_roundTrip(f *testing.F) {
+	f.Add("text", int64(-1), uint64(1), 1.5, true, int64(0))
+	f.Add("", int64(math.MinInt64), uint64(math.MaxUint64), math.NaN(), false, int64(1e18))
+
+	f.Fuzz(func(t *testing.T, s string, i int64, u uint64, fl float64, b bool, ns int64) {
+		for _, valid := range []bool{true, false} {
+			given := []nullable{
+				&nilt.String{String: s, Valid: valid},
+				&nilt.Int{Int: int(i), Valid: valid},
+				&nilt.Int8{Int8: int8(i), Valid: valid},
+				&nilt.Int16{Int16: int16(i), Valid: valid},
+				&nilt.Int32{Int32: int32(i), Valid: valid},
+				&nilt.Int64{Int64: i, Valid: valid},
+				&nilt.Uint{Uint: uint(u), Valid: valid},
+				&nilt.Uint8{Uint8: uint8(u), Valid: valid},
+				&nilt.Uint16{Uint16: uint16(u), Valid: valid},
+				&nilt.Uint32{Uint32: uint32(u), Valid: valid},
+				&nilt.Uint64{Uint64: u, Valid: valid},
+				&nilt.Float32{Float32: float32(fl), Valid: valid},
+				&nilt.Float64{Float64: fl, Valid: valid},
+				&nilt.Bool{Bool: b, Valid: valid},
+				&nilt.Time{Time: time.Unix(0, ns).UTC(), Valid: valid},
+				&nilt.Duration{Duration: time.Duration(ns), Valid: valid},
+				&nilt.Bytes{Bytes: []byte(s), Valid: valid},
+				&nilt.Of[string]{V: s, Valid: valid},
+			}
+			for _, e := range given {
+				testBinaryRoundTrip(t, e)
+			}
+		}
+	})
+}
+
+// FuzzBinary_UnmarshalBinary checks that arbitrary input either fails to decode
+// or decodes into a value that survives another round trip.
+func FuzzBinary_UnmarshalBinary(f *testing.F) {
+	for _, e := range examples {
+		b, err := e.MarshalBinary()
+		if err != nil {
+			f.Fatalf("%T: unexpected error: %s", e, err.Error())
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, e := range examples {
+			got := newLike(e)
+			if err := got.UnmarshalBinary(data); err != nil {
+				if got.Appear() {
+					t.Errorf("%T: expected invalid value after error for %v", e, data)
+				}
+				continue
+			}
+			testBinaryRoundTrip(t, got)
+		}
+	})
+}
+
+func testBinaryRoundTrip(t *testing.T, given nullable) {
+	t.Helper()
+
+	b, err := given.MarshalBinary()
+	if err != nil {
+		t.Errorf("%T: unexpected error: %s", given, err.Error())
+		return
+	}
+
+	got := newLike(given)
+	if err = got.UnmarshalBinary(b); err != nil {
+		t.Errorf("%T: unexpected error for %v: %s", given, b, err.Error())
+		return
+	}
+
+	again, err := got.MarshalBinary()
+	if err != nil {
+		t.Errorf("%T: unexpected error: %s", got, err.Error())
+		return
+	}
+	if !bytes.Equal(b, again) || got.Appear() != given.Appear() {
+		t.Errorf("%T: wrong output, expected %v but got %v", given, b, again)
+	}
+}
+
+func TestGob_symmetry(t *testing.T) {
+	for _, e := range examples {
+		b, err := e.GobEncode()
+		if err != nil {
+			t.Errorf("%T: unexpected error: %s", e, err.Error())
+			continue
+		}
+
+		got := newLike(e)
+		if err = got.GobDecode(b); err != nil {
+			t.Errorf("%T: unexpected error for %v: %s", e, b, err.Error())
+			continue
+		}
+
+		if !e.Appear() {
+			if !reflect.DeepEqual(got, newLike(e)) {
+				t.Errorf("%T: expected zero value after decoding %v, got %#v", e, b, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, e) {
+			t.Errorf("%T: wrong output for %v, expected %#v but got %#v", e, b, e, got)
+		}
+	}
+}
+
+func TestGob_GobEncode(t *testing.T) {
+	cases := map[string]struct {
+		given    gob.GobEncoder
+		expected []byte
+	}{
+		"invalid": {given: nilt.Int64{Int64: 1}, expected: []byte{1, 0}},
+		"int64":   {given: nilt.Int64{Int64: -1, Valid: true}, expected: []byte{1, 1, 1}},
+		"uint8":   {given: nilt.Uint8{Uint8: 255, Valid: true}, expected: []byte{1, 1, 255, 1}},
+		"float32": {given: nilt.Float32{Float32: 1, Valid: true}, expected: []byte{1, 1, 0x3f, 0x80, 0, 0}},
+		"bool":    {given: nilt.Bool{Bool: true, Valid: true}, expected: []byte{1, 1, 1}},
+		"string":  {given: nilt.String{String: "ab", Valid: true}, expected: []byte{1, 1, 'a', 'b'}},
+	}
+
+	for d, c := range cases {
+		b, err := c.given.GobEncode()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d, err.Error())
+			continue
+		}
+		if !bytes.Equal(b, c.expected) {
+			t.Errorf("%s: wrong output, expected %v but got %v", d, c.expected, b)
+		}
+	}
+}
+
+func TestGob_within(t *testing.T) {
+	type within struct {
+		ID      nilt.Int64
+		Name    nilt.String
+		Age     *nilt.Uint8
+		Created nilt.Time
+	}
+
+	given := within{
+		ID:      nilt.Int64{Int64: 1, Valid: true},
+		Name:    nilt.String{String: "john", Valid: true},
+		Age:     &nilt.Uint8{Uint8: 0, Valid: true},
+		Created: nilt.Time{Time: time.Date(2016, 4, 24, 12, 30, 15, 0, time.UTC), Valid: true},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(given); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var got within
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("wrong output, expected %#v but got %#v", given, got)
+	}
+}
+
+func TestGob_invalidInput(t *testing.T) {
+	cases := map[string]struct {
+		given nullable
+		data  []byte
+	}{
+		"empty":            {given: &nilt.Int64{}, data: []byte{}},
+		"unknown version":  {given: &nilt.Int64{}, data: []byte{2, 1, 1}},
+		"missing validity": {given: &nilt.Int64{}, data: []byte{1}},
+		"wrong validity":   {given: &nilt.Int64{}, data: []byte{1, 2}},
+		"trailing data":    {given: &nilt.Int64{}, data: []byte{1, 0, 1}},
+		"truncated varint": {given: &nilt.Int64{}, data: []byte{1, 1, 0x80}},
+		"out of range":     {given: &nilt.Int8{}, data: []byte{1, 1, 0x80, 0x02}},
+		"short float":      {given: &nilt.Float64{}, data: []byte{1, 1, 0, 0, 0, 0}},
+		"wrong bool":       {given: &nilt.Bool{}, data: []byte{1, 1, 2}},
+	}
+
+	for d, c := range cases {
+		if err := c.given.GobDecode(c.data); err == nil {
+			t.Errorf("%s: expected error", d)
+		}
+		if c.given.Appear() {
+			t.Errorf("%s: expected invalid value", d)
+		}
+	}
+}
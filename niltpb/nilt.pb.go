@@ -0,0 +1,1035 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: nilt.proto
+
+package niltpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type String struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *String) Reset() {
+	*x = String{}
+	mi := &file_nilt_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *String) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*String) ProtoMessage() {}
+
+func (x *String) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use String.ProtoReflect.Descriptor instead.
+func (*String) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *String) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *String) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Int64 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int64) Reset() {
+	*x = Int64{}
+	mi := &file_nilt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int64) ProtoMessage() {}
+
+func (x *Int64) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int64.ProtoReflect.Descriptor instead.
+func (*Int64) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Int64) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Int64) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Int8 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int8) Reset() {
+	*x = Int8{}
+	mi := &file_nilt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int8) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int8) ProtoMessage() {}
+
+func (x *Int8) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int8.ProtoReflect.Descriptor instead.
+func (*Int8) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Int8) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Int8) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Int16 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int16) Reset() {
+	*x = Int16{}
+	mi := &file_nilt_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int16) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int16) ProtoMessage() {}
+
+func (x *Int16) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int16.ProtoReflect.Descriptor instead.
+func (*Int16) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Int16) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Int16) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Int32 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int32) Reset() {
+	*x = Int32{}
+	mi := &file_nilt_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int32) ProtoMessage() {}
+
+func (x *Int32) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int32.ProtoReflect.Descriptor instead.
+func (*Int32) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Int32) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Int32) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+// Int represents Go int, which is encoded the same way as int64.
+type Int struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int) Reset() {
+	*x = Int{}
+	mi := &file_nilt_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int) ProtoMessage() {}
+
+func (x *Int) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int.ProtoReflect.Descriptor instead.
+func (*Int) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Int) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Int) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Uint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         uint64                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint) Reset() {
+	*x = Uint{}
+	mi := &file_nilt_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint) ProtoMessage() {}
+
+func (x *Uint) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint.ProtoReflect.Descriptor instead.
+func (*Uint) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Uint) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Uint) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Uint8 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         uint32                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint8) Reset() {
+	*x = Uint8{}
+	mi := &file_nilt_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint8) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint8) ProtoMessage() {}
+
+func (x *Uint8) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint8.ProtoReflect.Descriptor instead.
+func (*Uint8) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Uint8) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Uint8) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Uint16 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         uint32                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint16) Reset() {
+	*x = Uint16{}
+	mi := &file_nilt_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint16) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint16) ProtoMessage() {}
+
+func (x *Uint16) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint16.ProtoReflect.Descriptor instead.
+func (*Uint16) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Uint16) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Uint16) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Uint32 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         uint32                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32) Reset() {
+	*x = Uint32{}
+	mi := &file_nilt_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32) ProtoMessage() {}
+
+func (x *Uint32) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32.ProtoReflect.Descriptor instead.
+func (*Uint32) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Uint32) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Uint32) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Uint64 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         uint64                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint64) Reset() {
+	*x = Uint64{}
+	mi := &file_nilt_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint64) ProtoMessage() {}
+
+func (x *Uint64) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint64.ProtoReflect.Descriptor instead.
+func (*Uint64) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Uint64) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Uint64) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Float32 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float32                `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Float32) Reset() {
+	*x = Float32{}
+	mi := &file_nilt_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Float32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Float32) ProtoMessage() {}
+
+func (x *Float32) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Float32.ProtoReflect.Descriptor instead.
+func (*Float32) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Float32) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Float32) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Float64 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Float64) Reset() {
+	*x = Float64{}
+	mi := &file_nilt_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Float64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Float64) ProtoMessage() {}
+
+func (x *Float64) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Float64.ProtoReflect.Descriptor instead.
+func (*Float64) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Float64) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Float64) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Bool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         bool                   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bool) Reset() {
+	*x = Bool{}
+	mi := &file_nilt_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bool) ProtoMessage() {}
+
+func (x *Bool) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bool.ProtoReflect.Descriptor instead.
+func (*Bool) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Bool) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *Bool) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Bytes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bytes) Reset() {
+	*x = Bytes{}
+	mi := &file_nilt_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bytes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bytes) ProtoMessage() {}
+
+func (x *Bytes) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bytes.ProtoReflect.Descriptor instead.
+func (*Bytes) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Bytes) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Bytes) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Time struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Time) Reset() {
+	*x = Time{}
+	mi := &file_nilt_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Time) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Time) ProtoMessage() {}
+
+func (x *Time) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Time.ProtoReflect.Descriptor instead.
+func (*Time) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Time) GetValue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Time) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Duration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *durationpb.Duration   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Duration) Reset() {
+	*x = Duration{}
+	mi := &file_nilt_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Duration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Duration) ProtoMessage() {}
+
+func (x *Duration) ProtoReflect() protoreflect.Message {
+	mi := &file_nilt_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Duration.ProtoReflect.Descriptor instead.
+func (*Duration) Descriptor() ([]byte, []int) {
+	return file_nilt_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Duration) GetValue() *durationpb.Duration {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Duration) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+var File_nilt_proto protoreflect.FileDescriptor
+
+const file_nilt_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"nilt.proto\x12\x04nilt\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"4\n" +
+	"\x06String\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"3\n" +
+	"\x05Int64\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"2\n" +
+	"\x04Int8\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"3\n" +
+	"\x05Int16\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"3\n" +
+	"\x05Int32\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"1\n" +
+	"\x03Int\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"2\n" +
+	"\x04Uint\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x04R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"3\n" +
+	"\x05Uint8\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\rR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"4\n" +
+	"\x06Uint16\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\rR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"4\n" +
+	"\x06Uint32\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\rR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"4\n" +
+	"\x06Uint64\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x04R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"5\n" +
+	"\aFloat32\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x02R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"5\n" +
+	"\aFloat64\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"2\n" +
+	"\x04Bool\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\bR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"3\n" +
+	"\x05Bytes\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"N\n" +
+	"\x04Time\x120\n" +
+	"\x05value\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\"Q\n" +
+	"\bDuration\x12/\n" +
+	"\x05value\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05value\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05validB'Z%github.com/piotrkowalczuk/nilt/niltpbb\x06proto3"
+
+var (
+	file_nilt_proto_rawDescOnce sync.Once
+	file_nilt_proto_rawDescData []byte
+)
+
+func file_nilt_proto_rawDescGZIP() []byte {
+	file_nilt_proto_rawDescOnce.Do(func() {
+		file_nilt_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_nilt_proto_rawDesc), len(file_nilt_proto_rawDesc)))
+	})
+	return file_nilt_proto_rawDescData
+}
+
+var file_nilt_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_nilt_proto_goTypes = []any{
+	(*String)(nil),                // 0: nilt.String
+	(*Int64)(nil),                 // 1: nilt.Int64
+	(*Int8)(nil),                  // 2: nilt.Int8
+	(*Int16)(nil),                 // 3: nilt.Int16
+	(*Int32)(nil),                 // 4: nilt.Int32
+	(*Int)(nil),                   // 5: nilt.Int
+	(*Uint)(nil),                  // 6: nilt.Uint
+	(*Uint8)(nil),                 // 7: nilt.Uint8
+	(*Uint16)(nil),                // 8: nilt.Uint16
+	(*Uint32)(nil),                // 9: nilt.Uint32
+	(*Uint64)(nil),                // 10: nilt.Uint64
+	(*Float32)(nil),               // 11: nilt.Float32
+	(*Float64)(nil),               // 12: nilt.Float64
+	(*Bool)(nil),                  // 13: nilt.Bool
+	(*Bytes)(nil),                 // 14: nilt.Bytes
+	(*Time)(nil),                  // 15: nilt.Time
+	(*Duration)(nil),              // 16: nilt.Duration
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
+}
+var file_nilt_proto_depIdxs = []int32{
+	17, // 0: nilt.Time.value:type_name -> google.protobuf.Timestamp
+	18, // 1: nilt.Duration.value:type_name -> google.protobuf.Duration
+	2,  // [2:2] is the sub-list for method output_type
+	2,  // [2:2] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_nilt_proto_init() }
+func file_nilt_proto_init() {
+	if File_nilt_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nilt_proto_rawDesc), len(file_nilt_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_nilt_proto_goTypes,
+		DependencyIndexes: file_nilt_proto_depIdxs,
+		MessageInfos:      file_nilt_proto_msgTypes,
+	}.Build()
+	File_nilt_proto = out.File
+	file_nilt_proto_goTypes = nil
+	file_nilt_proto_depIdxs = nil
+}
@@ -0,0 +1,655 @@
+package nilt
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Scalar is the set of types that can be wrapped by Of.
+type Scalar interface {
+	string | int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64 | bool |
+		time.Time | time.Duration | []byte
+}
+
+// Uint64JSONString, if true, makes MarshalJSON of Uint64 encode valid values as strings,
+// so they survive JavaScript clients that cannot represent integers above 2^53 exactly.
+// UnmarshalJSON accepts both numbers and strings regardless of it.
+var Uint64JSONString = false
+
+// JSONObject, if true, makes MarshalJSON encode values in the object form, e.g. {"value":1,"valid":true},
+// the same as encoding/json encodes the struct fields, except that invalid value is always encoded as {}.
+// Otherwise valid values are encoded as JSON scalars and invalid values as null.
+// UnmarshalJSON accepts both forms regardless of it.
+// To omit invalid values instead, tag the fields with omitzero option, which makes use of IsZero.
+var JSONObject = false
+
+// Of represents a value of type T that may be nil.
+type Of[T Scalar] struct {
+	V     T    `json:"value,omitempty"`
+	Valid bool `json:"valid,omitempty"`
+}
+
+func (o *Of[T]) ref() ref[T] {
+	if o == nil {
+		return ref[T]{}
+	}
+	return ref[T]{v: &o.V, valid: &o.Valid}
+}
+
+func (o *Of[T]) unwrap() (interface{}, bool) {
+	return o.ref().unwrap()
+}
+
+// Or returns given value if receiver is nil or invalid.
+func (o *Of[T]) Or(or T) T {
+	return o.ref().or(or)
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (o *Of[T]) Ptr() *T {
+	return o.ref().ptr()
+}
+
+// Appear implements pqcomp Appearer interface.
+func (o *Of[T]) Appear() bool {
+	return o.ref().appear()
+}
+
+// Value implements the driver Valuer interface.
+func (o Of[T]) Value() (driver.Value, error) {
+	return o.ref().value()
+}
+
+// Scan implements the Scanner interface.
+func (o *Of[T]) Scan(value interface{}) error {
+	return o.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (o Of[T]) MarshalJSON() ([]byte, error) {
+	return o.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (o *Of[T]) UnmarshalJSON(data []byte) error {
+	return o.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (o Of[T]) AppendJSON(dst []byte) ([]byte, error) {
+	return o.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (o Of[T]) IsZero() bool {
+	return o.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (o Of[T]) MarshalText() ([]byte, error) {
+	return o.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (o *Of[T]) UnmarshalText(text []byte) error {
+	return o.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (o Of[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return o.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (o *Of[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return o.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (o Of[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return o.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (o *Of[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return o.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (o Of[T]) MarshalBinary() ([]byte, error) {
+	return o.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (o Of[T]) AppendBinary(b []byte) ([]byte, error) {
+	return o.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (o *Of[T]) UnmarshalBinary(data []byte) error {
+	return o.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (o Of[T]) GobEncode() ([]byte, error) {
+	return o.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (o *Of[T]) GobDecode(data []byte) error {
+	return o.ref().gobDecode(data)
+}
+
+// ref points to the value and the validity flag of a nullable type.
+// It holds the single implementation shared by Of and the named types,
+// which differ only in the name of the value field.
+// Zero ref behaves like a nil receiver, it is invalid and cannot be written to.
+type ref[T Scalar] struct {
+	v     *T
+	valid *bool
+}
+
+func (r ref[T]) ok() bool {
+	return r.valid != nil && *r.valid
+}
+
+func (r ref[T]) reset() {
+	var zero T
+	*r.v, *r.valid = zero, false
+}
+
+func (r ref[T]) or(or T) T {
+	if !r.ok() {
+		return or
+	}
+
+	return *r.v
+}
+
+func (r ref[T]) ptr() *T {
+	if !r.ok() {
+		return nil
+	}
+
+	v := *r.v
+	return &v
+}
+
+func (r ref[T]) unwrap() (interface{}, bool) {
+	var v T
+	if r.v != nil {
+		v = *r.v
+	}
+
+	return v, r.ok()
+}
+
+func (r ref[T]) appear() bool {
+	return r.ok()
+}
+
+func (r ref[T]) isZero() bool {
+	return !r.ok()
+}
+
+func (r ref[T]) value() (driver.Value, error) {
+	if !r.ok() {
+		return nil, nil
+	}
+
+	switch v := any(*r.v).(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return valueUint(uint64(v)), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return valueUint(v), nil
+	case float32:
+		return float64(v), nil
+	case time.Duration:
+		return int64(v), nil
+	case []byte:
+		if v == nil {
+			return []byte{}, nil
+		}
+	}
+
+	return *r.v, nil
+}
+
+func (r ref[T]) scan(value interface{}) (err error) {
+	if value == nil {
+		r.reset()
+		return nil
+	}
+
+	var (
+		i int64
+		u uint64
+		f float64
+	)
+	switch p := any(r.v).(type) {
+	case *string:
+		*p, err = scanString(value)
+	case *int:
+		i, err = scanInt(value, strconv.IntSize)
+		*p = int(i)
+	case *int8:
+		i, err = scanInt(value, 8)
+		*p = int8(i)
+	case *int16:
+		i, err = scanInt(value, 16)
+		*p = int16(i)
+	case *int32:
+		i, err = scanInt(value, 32)
+		*p = int32(i)
+	case *int64:
+		*p, err = scanInt(value, 64)
+	case *uint:
+		u, err = scanUint(value, strconv.IntSize)
+		*p = uint(u)
+	case *uint8:
+		u, err = scanUint(value, 8)
+		*p = uint8(u)
+	case *uint16:
+		u, err = scanUint(value, 16)
+		*p = uint16(u)
+	case *uint32:
+		u, err = scanUint(value, 32)
+		*p = uint32(u)
+	case *uint64:
+		*p, err = scanUint(value, 64)
+	case *float32:
+		f, err = scanFloat(value, 32)
+		*p = float32(f)
+	case *float64:
+		*p, err = scanFloat(value, 64)
+	case *bool:
+		*p, err = scanBool(value)
+	case *time.Time:
+		*p, err = scanTime(value)
+	case *time.Duration:
+		*p, err = scanDuration(value)
+	case *[]byte:
+		*p, err = scanBytes(value)
+	}
+
+	if errors.Is(err, errUnsupportedType) {
+		err = fmt.Errorf("nilt: unsupported type (%T) passed to Scan of %T", value, *r.v)
+	}
+	if err != nil {
+		r.reset()
+		return err
+	}
+
+	*r.valid = true
+	return nil
+}
+
+func (r ref[T]) marshalJSON() ([]byte, error) {
+	// enough for any number, so it is the only allocation
+	return r.appendJSON(make([]byte, 0, 32))
+}
+
+func (r ref[T]) appendJSON(b []byte) ([]byte, error) {
+	if JSONObject {
+		return r.appendJSONObject(b)
+	}
+
+	return r.appendJSONScalar(b)
+}
+
+func (r ref[T]) appendJSONScalar(b []byte) ([]byte, error) {
+	if !r.ok() {
+		return append(b, "null"...), nil
+	}
+
+	switch v := any(*r.v).(type) {
+	case string:
+		return appendJSONString(b, v), nil
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("nilt: unsupported value: %v", v)
+		}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("nilt: unsupported value: %v", v)
+		}
+	case time.Duration:
+		if DurationJSONFormat == DurationFormatNanoseconds {
+			return strconv.AppendInt(b, int64(v), 10), nil
+		}
+		return r.appendQuotedText(b)
+	case uint64:
+		if Uint64JSONString {
+			return r.appendQuotedText(b)
+		}
+	case time.Time, []byte:
+		return r.appendQuotedText(b)
+	}
+
+	return r.appendText(b)
+}
+
+// appendJSONObject encodes the value the same as encoding/json encodes the struct fields,
+// value field is omitted if it is empty, according to omitempty option.
+func (r ref[T]) appendJSONObject(b []byte) ([]byte, error) {
+	if !r.ok() {
+		return append(b, "{}"...), nil
+	}
+
+	b = append(b, '{')
+	if !isEmpty(*r.v) {
+		var err error
+		b = append(b, `"value":`...)
+		if b, err = r.appendJSONScalar(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+
+	return append(b, `"valid":true}`...), nil
+}
+
+// appendJSONString appends s as JSON string, escaped the same as encoding/json does by default.
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			b = append(b, s[start:i]...)
+			b = utf8.AppendRune(b, utf8.RuneError)
+		case c == '\u2028' || c == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[c&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+
+	return append(b, '"')
+}
+
+// isEmpty reports whether v is omitted by encoding/json, if the field is tagged with omitempty option.
+func isEmpty(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice:
+		return rv.Len() == 0
+	case reflect.Struct:
+		return false
+	}
+
+	return rv.IsZero()
+}
+
+func (r ref[T]) unmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullJSON) {
+		r.reset()
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		return r.unmarshalJSONObject(data)
+	}
+
+	var v T
+	if err := decodeJSON(data, &v); err != nil {
+		r.reset()
+		return err
+	}
+
+	*r.v, *r.valid = v, true
+	return nil
+}
+
+// unmarshalJSONObject decodes the object form, missing or null value field means empty value.
+func (r ref[T]) unmarshalJSONObject(data []byte) error {
+	var o struct {
+		Value json.RawMessage `json:"value"`
+		Valid bool            `json:"valid"`
+	}
+	if err := json.Unmarshal(data, &o); err != nil {
+		r.reset()
+		return err
+	}
+
+	var v T
+	if o.Valid && len(o.Value) > 0 && !bytes.Equal(o.Value, nullJSON) {
+		if err := decodeJSON(o.Value, &v); err != nil {
+			r.reset()
+			return err
+		}
+	} else if p, ok := any(&v).(*[]byte); ok && o.Valid {
+		// omitted empty slice, the same as "" in the scalar form
+		*p = []byte{}
+	}
+
+	*r.v, *r.valid = v, o.Valid
+	return nil
+}
+
+var nullJSON = []byte("null")
+
+// decodeJSON decodes non-null JSON value into v.
+// Besides representations handled by encoding/json, it accepts strings produced by
+// MarshalJSON depending on DurationJSONFormat and Uint64JSONString.
+func decodeJSON(data []byte, v interface{}) error {
+	if len(data) == 0 || data[0] != '"' {
+		return json.Unmarshal(data, v)
+	}
+
+	switch p := v.(type) {
+	case *time.Duration:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*p = d
+		return nil
+	case *uint64:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*p = u
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// valueUint converts v into driver.Value, values that do not fit into int64 are passed as decimal strings.
+func valueUint(v uint64) driver.Value {
+	if v > math.MaxInt64 {
+		return strconv.FormatUint(v, 10)
+	}
+	return int64(v)
+}
+
+var errUnsupportedType = errors.New("nilt: unsupported type")
+
+func scanString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+
+	return "", errUnsupportedType
+}
+
+func scanInt(value interface{}, bitSize int) (int64, error) {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.ParseInt(string(v), 10, bitSize)
+	case string:
+		return strconv.ParseInt(v, 10, bitSize)
+	case int64:
+		if bitSize < 64 && (v < -1<<(bitSize-1) || v > 1<<(bitSize-1)-1) {
+			return 0, fmt.Errorf("nilt: value %d is out of range of %d-bit signed integer", v, bitSize)
+		}
+		return v, nil
+	case uint64:
+		if v > 1<<(bitSize-1)-1 {
+			return 0, fmt.Errorf("nilt: value %d is out of range of %d-bit signed integer", v, bitSize)
+		}
+		return int64(v), nil
+	}
+
+	return 0, errUnsupportedType
+}
+
+func scanUint(value interface{}, bitSize int) (uint64, error) {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.ParseUint(string(v), 10, bitSize)
+	case string:
+		return strconv.ParseUint(v, 10, bitSize)
+	case int64:
+		if v < 0 || (bitSize < 64 && uint64(v) > 1<<bitSize-1) {
+			return 0, fmt.Errorf("nilt: value %d is out of range of %d-bit unsigned integer", v, bitSize)
+		}
+		return uint64(v), nil
+	case uint64:
+		if bitSize < 64 && v > 1<<bitSize-1 {
+			return 0, fmt.Errorf("nilt: value %d is out of range of %d-bit unsigned integer", v, bitSize)
+		}
+		return v, nil
+	}
+
+	return 0, errUnsupportedType
+}
+
+func scanFloat(value interface{}, bitSize int) (float64, error) {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.ParseFloat(string(v), bitSize)
+	case string:
+		return strconv.ParseFloat(v, bitSize)
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+
+	return 0, errUnsupportedType
+}
+
+func scanBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.ParseBool(string(v))
+	case string:
+		return strconv.ParseBool(v)
+	case bool:
+		return v, nil
+	}
+
+	return false, errUnsupportedType
+}
+
+func scanTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case []byte:
+		return time.Parse(time.RFC3339Nano, string(v))
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	case time.Time:
+		return v, nil
+	}
+
+	return time.Time{}, errUnsupportedType
+}
+
+func scanDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case []byte:
+		return parseDuration(string(v))
+	case string:
+		return parseDuration(v)
+	case int64:
+		return time.Duration(v), nil
+	}
+
+	return 0, errUnsupportedType
+}
+
+func scanBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return bytes.Clone(v), nil
+	case string:
+		return []byte(v), nil
+	}
+
+	return nil, errUnsupportedType
+}
@@ -0,0 +1,511 @@
+package nilt
+
+//go:generate protoc --go_out=. --go_opt=module=github.com/piotrkowalczuk/nilt nilt.proto
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt/niltpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Message types of the nilt types, described by the messages generated from nilt.proto into niltpb package.
+// They make the nilt types messages of google.golang.org/protobuf (APIv2),
+// so they can be used with proto, protojson, protoreflect and gRPC, while keeping their layout and methods.
+var (
+	stringType   = newMessageType[string, *String](&niltpb.String{})
+	int8Type     = newMessageType[int8, *Int8](&niltpb.Int8{})
+	int16Type    = newMessageType[int16, *Int16](&niltpb.Int16{})
+	int32Type    = newMessageType[int32, *Int32](&niltpb.Int32{})
+	int64Type    = newMessageType[int64, *Int64](&niltpb.Int64{})
+	intType      = newMessageType[int, *Int](&niltpb.Int{})
+	uintType     = newMessageType[uint, *Uint](&niltpb.Uint{})
+	uint8Type    = newMessageType[uint8, *Uint8](&niltpb.Uint8{})
+	uint16Type   = newMessageType[uint16, *Uint16](&niltpb.Uint16{})
+	uint32Type   = newMessageType[uint32, *Uint32](&niltpb.Uint32{})
+	uint64Type   = newMessageType[uint64, *Uint64](&niltpb.Uint64{})
+	float32Type  = newMessageType[float32, *Float32](&niltpb.Float32{})
+	float64Type  = newMessageType[float64, *Float64](&niltpb.Float64{})
+	boolType     = newMessageType[bool, *Bool](&niltpb.Bool{})
+	bytesType    = newMessageType[[]byte, *Bytes](&niltpb.Bytes{})
+	timeType     = newMessageType[time.Time, *Time](&niltpb.Time{})
+	durationType = newMessageType[time.Duration, *Duration](&niltpb.Duration{})
+)
+
+// protoMessage is implemented by pointers to the nilt types that have a corresponding message in nilt.proto.
+type protoMessage[T Scalar] interface {
+	protoreflect.ProtoMessage
+	ref() ref[T]
+}
+
+// messageType implements protoreflect.MessageType of a nilt type.
+// Wire format is decoded into pb, the generated message of the same descriptor.
+type messageType[T Scalar] struct {
+	desc protoreflect.MessageDescriptor
+	pb   protoreflect.MessageType
+	new  func() protoMessage[T]
+	zero protoMessage[T]
+}
+
+func newMessageType[T Scalar, P interface {
+	*M
+	protoMessage[T]
+}, M any](pb protoreflect.ProtoMessage) *messageType[T] {
+	return &messageType[T]{
+		desc: pb.ProtoReflect().Descriptor(),
+		pb:   pb.ProtoReflect().Type(),
+		new:  func() protoMessage[T] { return P(new(M)) },
+		zero: P(nil),
+	}
+}
+
+// methods makes proto.Unmarshal report values that do not fit into the value type.
+var methods = &protoiface.Methods{
+	Flags: protoiface.SupportUnmarshalDiscardUnknown,
+	Unmarshal: func(in protoiface.UnmarshalInput) (protoiface.UnmarshalOutput, error) {
+		return protoiface.UnmarshalOutput{}, in.Message.(interface {
+			unmarshal(protoiface.UnmarshalInput) error
+		}).unmarshal(in)
+	},
+}
+
+func (t *messageType[T]) New() protoreflect.Message {
+	return t.message(t.new())
+}
+
+func (t *messageType[T]) Zero() protoreflect.Message {
+	return t.message(t.zero)
+}
+
+func (t *messageType[T]) Descriptor() protoreflect.MessageDescriptor {
+	return t.desc
+}
+
+func (t *messageType[T]) message(m protoMessage[T]) protoreflect.Message {
+	return &message[T]{typ: t, m: m}
+}
+
+// message implements protoreflect.Message of a nilt type.
+// Field 1 is the value, field 2 is the validity flag. Unknown fields are discarded.
+type message[T Scalar] struct {
+	typ *messageType[T]
+	m   protoMessage[T]
+}
+
+func (m *message[T]) Descriptor() protoreflect.MessageDescriptor { return m.typ.desc }
+func (m *message[T]) Type() protoreflect.MessageType             { return m.typ }
+func (m *message[T]) New() protoreflect.Message                  { return m.typ.New() }
+func (m *message[T]) Interface() protoreflect.ProtoMessage       { return m.m }
+func (m *message[T]) ProtoMethods() *protoiface.Methods          { return methods }
+func (m *message[T]) IsValid() bool                              { return m.m.ref().valid != nil }
+
+func (m *message[T]) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	fields := m.typ.desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); m.Has(fd) && !f(fd, m.Get(fd)) {
+			return
+		}
+	}
+}
+
+func (m *message[T]) Has(fd protoreflect.FieldDescriptor) bool {
+	r := m.m.ref()
+	if m.field(fd) == 2 {
+		return r.ok()
+	}
+	if r.v == nil {
+		return false
+	}
+
+	switch v := any(*r.v).(type) {
+	case time.Time:
+		return !v.IsZero()
+	case []byte:
+		return len(v) > 0
+	}
+	return !reflect.ValueOf(*r.v).IsZero()
+}
+
+func (m *message[T]) Clear(fd protoreflect.FieldDescriptor) {
+	r := m.m.ref()
+	if m.field(fd) == 2 {
+		*r.valid = false
+		return
+	}
+
+	var zero T
+	*r.v = zero
+}
+
+func (m *message[T]) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	r := m.m.ref()
+	if m.field(fd) == 2 {
+		return protoreflect.ValueOfBool(r.ok())
+	}
+
+	var v T
+	if r.v != nil {
+		v = *r.v
+	}
+	switch v := any(v).(type) {
+	case string:
+		return protoreflect.ValueOfString(v)
+	case int8:
+		return protoreflect.ValueOfInt32(int32(v))
+	case int16:
+		return protoreflect.ValueOfInt32(int32(v))
+	case int32:
+		return protoreflect.ValueOfInt32(v)
+	case int:
+		return protoreflect.ValueOfInt64(int64(v))
+	case int64:
+		return protoreflect.ValueOfInt64(v)
+	case uint8:
+		return protoreflect.ValueOfUint32(uint32(v))
+	case uint16:
+		return protoreflect.ValueOfUint32(uint32(v))
+	case uint32:
+		return protoreflect.ValueOfUint32(v)
+	case uint:
+		return protoreflect.ValueOfUint64(uint64(v))
+	case uint64:
+		return protoreflect.ValueOfUint64(v)
+	case float32:
+		return protoreflect.ValueOfFloat32(v)
+	case float64:
+		return protoreflect.ValueOfFloat64(v)
+	case bool:
+		return protoreflect.ValueOfBool(v)
+	case []byte:
+		return protoreflect.ValueOfBytes(v)
+	case time.Time:
+		if !m.Has(fd) {
+			return protoreflect.ValueOfMessage((*timestamppb.Timestamp)(nil).ProtoReflect())
+		}
+		return protoreflect.ValueOfMessage(timestamppb.New(v).ProtoReflect())
+	case time.Duration:
+		if !m.Has(fd) {
+			return protoreflect.ValueOfMessage((*durationpb.Duration)(nil).ProtoReflect())
+		}
+		return protoreflect.ValueOfMessage(durationpb.New(v).ProtoReflect())
+	}
+
+	panic("nilt: unsupported type")
+}
+
+// Set stores given value. Integer or duration that does not fit into the value type is not stored,
+// as Set cannot report an error. proto.Unmarshal does not use Set and reports such a value.
+func (m *message[T]) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	_ = m.set(fd, v)
+}
+
+// set stores given value, unless it does not fit into the value type.
+func (m *message[T]) set(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	r := m.m.ref()
+	if m.field(fd) == 2 {
+		*r.valid = v.Bool()
+		return nil
+	}
+
+	var err error
+	switch p := any(r.v).(type) {
+	case *string:
+		*p = v.String()
+	case *int8:
+		err = setInt(p, v.Int(), 8)
+	case *int16:
+		err = setInt(p, v.Int(), 16)
+	case *int32:
+		*p = int32(v.Int())
+	case *int:
+		err = setInt(p, v.Int(), strconv.IntSize)
+	case *int64:
+		*p = v.Int()
+	case *uint8:
+		err = setUint(p, v.Uint(), 8)
+	case *uint16:
+		err = setUint(p, v.Uint(), 16)
+	case *uint32:
+		*p = uint32(v.Uint())
+	case *uint:
+		err = setUint(p, v.Uint(), strconv.IntSize)
+	case *uint64:
+		*p = v.Uint()
+	case *float32:
+		*p = float32(v.Float())
+	case *float64:
+		*p = v.Float()
+	case *bool:
+		*p = v.Bool()
+	case *[]byte:
+		*p = v.Bytes()
+	case *time.Time:
+		sec, nsec := secondsOf(v.Message())
+		*p = time.Unix(sec, int64(nsec)).UTC()
+	case *time.Duration:
+		sec, nsec := secondsOf(v.Message())
+		err = setDuration(p, sec, nsec)
+	}
+	return err
+}
+
+func (m *message[T]) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	r := m.m.ref()
+	if m.field(fd) == 1 {
+		switch p := any(r.v).(type) {
+		case *time.Time:
+			if p.IsZero() {
+				*p = time.Unix(0, 0).UTC()
+			}
+			return protoreflect.ValueOfMessage(&secondsMessage{
+				base: (*timestamppb.Timestamp)(nil).ProtoReflect(),
+				get:  func() (int64, int32) { return p.Unix(), int32(p.Nanosecond()) },
+				set:  func(sec int64, nsec int32) { *p = time.Unix(sec, int64(nsec)).UTC() },
+			})
+		case *time.Duration:
+			return protoreflect.ValueOfMessage(&secondsMessage{
+				base: (*durationpb.Duration)(nil).ProtoReflect(),
+				get:  func() (int64, int32) { return int64(*p / time.Second), int32(*p % time.Second) },
+				set:  func(sec int64, nsec int32) { _ = setDuration(p, sec, nsec) },
+			})
+		}
+	}
+
+	panic("nilt: " + string(fd.FullName()) + " is not a message field")
+}
+
+func (m *message[T]) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if m.field(fd) == 1 {
+		switch any(m.m.ref().v).(type) {
+		case *time.Time:
+			return protoreflect.ValueOfMessage((&timestamppb.Timestamp{}).ProtoReflect())
+		case *time.Duration:
+			return protoreflect.ValueOfMessage((&durationpb.Duration{}).ProtoReflect())
+		}
+	}
+
+	return fd.Default()
+}
+
+func (m *message[T]) WhichOneof(protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	return nil
+}
+
+func (m *message[T]) GetUnknown() protoreflect.RawFields { return nil }
+func (m *message[T]) SetUnknown(protoreflect.RawFields)  {}
+
+// unmarshal decodes the wire format into the generated message, merged with the current value,
+// and stores its fields. It fails if the value does not fit into the value type.
+func (m *message[T]) unmarshal(in protoiface.UnmarshalInput) error {
+	pb := m.typ.pb.New()
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		pb.Set(fd, v)
+		return true
+	})
+
+	opts := proto.UnmarshalOptions{
+		Merge:          true,
+		AllowPartial:   true,
+		DiscardUnknown: true,
+		Resolver:       in.Resolver,
+		RecursionLimit: in.Depth,
+	}
+	if err := opts.Unmarshal(in.Buf, pb.Interface()); err != nil {
+		return err
+	}
+
+	m.m.ref().reset()
+	var err error
+	pb.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		err = m.set(fd, v)
+		return err == nil
+	})
+	return err
+}
+
+// field returns number of given field, it panics if the field does not belong to the message.
+func (m *message[T]) field(fd protoreflect.FieldDescriptor) protoreflect.FieldNumber {
+	if fd.ContainingMessage().FullName() != m.typ.desc.FullName() {
+		panic("nilt: field " + string(fd.FullName()) + " does not belong to " + string(m.typ.desc.FullName()))
+	}
+
+	return fd.Number()
+}
+
+func setInt[N int | int8 | int16](p *N, v int64, bitSize int) error {
+	n, err := scanInt(v, bitSize)
+	if err == nil {
+		*p = N(n)
+	}
+	return err
+}
+
+func setUint[N uint | uint8 | uint16](p *N, v uint64, bitSize int) error {
+	n, err := scanUint(v, bitSize)
+	if err == nil {
+		*p = N(n)
+	}
+	return err
+}
+
+// setDuration stores duration made of given seconds and nanoseconds, unless it does not fit into time.Duration.
+func setDuration(p *time.Duration, sec int64, nsec int32) error {
+	d := time.Duration(sec) * time.Second
+	n := d + time.Duration(nsec)
+	if d/time.Second != time.Duration(sec) || (nsec > 0 && n < d) || (nsec < 0 && n > d) {
+		return fmt.Errorf("nilt: duration of %d seconds and %d nanoseconds is out of range of time.Duration", sec, nsec)
+	}
+
+	*p = n
+	return nil
+}
+
+// secondsOf returns fields of google.protobuf.Timestamp or google.protobuf.Duration message.
+func secondsOf(m protoreflect.Message) (int64, int32) {
+	fields := m.Descriptor().Fields()
+	return m.Get(fields.ByNumber(1)).Int(), int32(m.Get(fields.ByNumber(2)).Int())
+}
+
+// secondsMessage implements protoreflect.Message of google.protobuf.Timestamp or google.protobuf.Duration,
+// that is the value of Time or Duration, so it can be modified in place, e.g. by proto.Merge.
+type secondsMessage struct {
+	base protoreflect.Message
+	get  func() (int64, int32)
+	set  func(int64, int32)
+}
+
+func (m *secondsMessage) ProtoReflect() protoreflect.Message         { return m }
+func (m *secondsMessage) Descriptor() protoreflect.MessageDescriptor { return m.base.Descriptor() }
+func (m *secondsMessage) Type() protoreflect.MessageType             { return m.base.Type() }
+func (m *secondsMessage) New() protoreflect.Message                  { return m.base.New() }
+func (m *secondsMessage) Interface() protoreflect.ProtoMessage       { return m }
+func (m *secondsMessage) ProtoMethods() *protoiface.Methods          { return nil }
+func (m *secondsMessage) IsValid() bool                              { return true }
+func (m *secondsMessage) GetUnknown() protoreflect.RawFields         { return nil }
+func (m *secondsMessage) SetUnknown(protoreflect.RawFields)          {}
+func (m *secondsMessage) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	return fd.Default()
+}
+
+func (m *secondsMessage) WhichOneof(protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	return nil
+}
+
+func (m *secondsMessage) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); m.Has(fd) && !f(fd, m.Get(fd)) {
+			return
+		}
+	}
+}
+
+func (m *secondsMessage) Has(fd protoreflect.FieldDescriptor) bool {
+	return m.Get(fd).Int() != 0
+}
+
+func (m *secondsMessage) Clear(fd protoreflect.FieldDescriptor) {
+	m.Set(fd, protoreflect.ValueOfInt64(0))
+}
+
+func (m *secondsMessage) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	sec, nsec := m.get()
+	if fd.Number() == 1 {
+		return protoreflect.ValueOfInt64(sec)
+	}
+	return protoreflect.ValueOfInt32(nsec)
+}
+
+func (m *secondsMessage) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	sec, nsec := m.get()
+	if fd.Number() == 1 {
+		sec = v.Int()
+	} else {
+		nsec = int32(v.Int())
+	}
+	m.set(sec, nsec)
+}
+
+func (m *secondsMessage) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	panic("nilt: " + string(fd.FullName()) + " is not a message field")
+}
+
+// compactText returns the message in compact text format, the same as produced by proto.CompactTextString.
+// Fields are written in order of declaration, unlike Range, which does not guarantee any order.
+func compactText(m protoreflect.Message) string {
+	var b []byte
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+
+		b = append(b, fd.Name()...)
+		b = append(b, ':')
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			b = appendQuotedText(b, v.String())
+		case protoreflect.BytesKind:
+			b = appendQuotedText(b, string(v.Bytes()))
+		case protoreflect.MessageKind:
+			b = append(b, '<')
+			b = append(b, compactText(v.Message())...)
+			b = append(b, '>')
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			switch f := v.Float(); {
+			case math.IsInf(f, 1):
+				b = append(b, "inf"...)
+			case math.IsInf(f, -1):
+				b = append(b, "-inf"...)
+			case math.IsNaN(f):
+				b = append(b, "nan"...)
+			case fd.Kind() == protoreflect.FloatKind:
+				b = strconv.AppendFloat(b, f, 'g', -1, 32)
+			default:
+				b = strconv.AppendFloat(b, f, 'g', -1, 64)
+			}
+		default:
+			b = fmt.Append(b, v.Interface())
+		}
+		b = append(b, ' ')
+	}
+
+	return string(b)
+}
+
+// appendQuotedText appends s quoted the way text format does, non-printable bytes are escaped using octal notation.
+func appendQuotedText(b []byte, s string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			b = append(b, `\n`...)
+		case '\r':
+			b = append(b, `\r`...)
+		case '\t':
+			b = append(b, `\t`...)
+		case '"':
+			b = append(b, `\"`...)
+		case '\\':
+			b = append(b, `\\`...)
+		default:
+			if c >= 0x20 && c < 0x7f {
+				b = append(b, c)
+			} else {
+				b = fmt.Appendf(b, `\%03o`, c)
+			}
+		}
+	}
+
+	return append(b, '"')
+}
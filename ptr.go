@@ -0,0 +1,405 @@
+package nilt
+
+import "time"
+
+// Conversions between the nilt types and pointers, e.g. fields generated for proto3 optional fields.
+// Nil pointer maps to invalid value and vice versa.
+
+// FromPtr returns Of holding value pointed by p.
+func FromPtr[T Scalar](p *T) Of[T] {
+	if p == nil {
+		return Of[T]{}
+	}
+	return Of[T]{V: *p, Valid: true}
+}
+
+// FromPtrs converts each element of ps using FromPtr.
+func FromPtrs[T Scalar](ps []*T) []Of[T] {
+	return fromPtrs(ps, FromPtr[T])
+}
+
+// Ptrs converts each element of vs using Ptr method.
+func Ptrs[T Scalar](vs []Of[T]) []*T {
+	return ptrs(vs, (*Of[T]).Ptr)
+}
+
+func fromPtrs[T Scalar, N any](ps []*T, fn func(*T) N) []N {
+	if ps == nil {
+		return nil
+	}
+
+	vs := make([]N, len(ps))
+	for i, p := range ps {
+		vs[i] = fn(p)
+	}
+	return vs
+}
+
+func ptrs[T Scalar, N any](vs []N, fn func(*N) *T) []*T {
+	if vs == nil {
+		return nil
+	}
+
+	ps := make([]*T, len(vs))
+	for i := range vs {
+		ps[i] = fn(&vs[i])
+	}
+	return ps
+}
+
+// StringFromPtr returns String holding value pointed by p.
+func StringFromPtr(p *string) String {
+	v := FromPtr(p)
+	return String{String: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (s *String) Ptr() *string {
+	return s.ref().ptr()
+}
+
+// StringFromPtrs converts each element of ps using StringFromPtr.
+func StringFromPtrs(ps []*string) []String {
+	return fromPtrs(ps, StringFromPtr)
+}
+
+// StringPtrs converts each element of vs using Ptr method.
+func StringPtrs(vs []String) []*string {
+	return ptrs(vs, (*String).Ptr)
+}
+
+// IntFromPtr returns Int holding value pointed by p.
+func IntFromPtr(p *int) Int {
+	v := FromPtr(p)
+	return Int{Int: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (i *Int) Ptr() *int {
+	return i.ref().ptr()
+}
+
+// IntFromPtrs converts each element of ps using IntFromPtr.
+func IntFromPtrs(ps []*int) []Int {
+	return fromPtrs(ps, IntFromPtr)
+}
+
+// IntPtrs converts each element of vs using Ptr method.
+func IntPtrs(vs []Int) []*int {
+	return ptrs(vs, (*Int).Ptr)
+}
+
+// Int8FromPtr returns Int8 holding value pointed by p.
+func Int8FromPtr(p *int8) Int8 {
+	v := FromPtr(p)
+	return Int8{Int8: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (i *Int8) Ptr() *int8 {
+	return i.ref().ptr()
+}
+
+// Int8FromPtrs converts each element of ps using Int8FromPtr.
+func Int8FromPtrs(ps []*int8) []Int8 {
+	return fromPtrs(ps, Int8FromPtr)
+}
+
+// Int8Ptrs converts each element of vs using Ptr method.
+func Int8Ptrs(vs []Int8) []*int8 {
+	return ptrs(vs, (*Int8).Ptr)
+}
+
+// Int16FromPtr returns Int16 holding value pointed by p.
+func Int16FromPtr(p *int16) Int16 {
+	v := FromPtr(p)
+	return Int16{Int16: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (i *Int16) Ptr() *int16 {
+	return i.ref().ptr()
+}
+
+// Int16FromPtrs converts each element of ps using Int16FromPtr.
+func Int16FromPtrs(ps []*int16) []Int16 {
+	return fromPtrs(ps, Int16FromPtr)
+}
+
+// Int16Ptrs converts each element of vs using Ptr method.
+func Int16Ptrs(vs []Int16) []*int16 {
+	return ptrs(vs, (*Int16).Ptr)
+}
+
+// Int32FromPtr returns Int32 holding value pointed by p.
+func Int32FromPtr(p *int32) Int32 {
+	v := FromPtr(p)
+	return Int32{Int32: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (i *Int32) Ptr() *int32 {
+	return i.ref().ptr()
+}
+
+// Int32FromPtrs converts each element of ps using Int32FromPtr.
+func Int32FromPtrs(ps []*int32) []Int32 {
+	return fromPtrs(ps, Int32FromPtr)
+}
+
+// Int32Ptrs converts each element of vs using Ptr method.
+func Int32Ptrs(vs []Int32) []*int32 {
+	return ptrs(vs, (*Int32).Ptr)
+}
+
+// Int64FromPtr returns Int64 holding value pointed by p.
+func Int64FromPtr(p *int64) Int64 {
+	v := FromPtr(p)
+	return Int64{Int64: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (i *Int64) Ptr() *int64 {
+	return i.ref().ptr()
+}
+
+// Int64FromPtrs converts each element of ps using Int64FromPtr.
+func Int64FromPtrs(ps []*int64) []Int64 {
+	return fromPtrs(ps, Int64FromPtr)
+}
+
+// Int64Ptrs converts each element of vs using Ptr method.
+func Int64Ptrs(vs []Int64) []*int64 {
+	return ptrs(vs, (*Int64).Ptr)
+}
+
+// UintFromPtr returns Uint holding value pointed by p.
+func UintFromPtr(p *uint) Uint {
+	v := FromPtr(p)
+	return Uint{Uint: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (u *Uint) Ptr() *uint {
+	return u.ref().ptr()
+}
+
+// UintFromPtrs converts each element of ps using UintFromPtr.
+func UintFromPtrs(ps []*uint) []Uint {
+	return fromPtrs(ps, UintFromPtr)
+}
+
+// UintPtrs converts each element of vs using Ptr method.
+func UintPtrs(vs []Uint) []*uint {
+	return ptrs(vs, (*Uint).Ptr)
+}
+
+// Uint8FromPtr returns Uint8 holding value pointed by p.
+func Uint8FromPtr(p *uint8) Uint8 {
+	v := FromPtr(p)
+	return Uint8{Uint8: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (u *Uint8) Ptr() *uint8 {
+	return u.ref().ptr()
+}
+
+// Uint8FromPtrs converts each element of ps using Uint8FromPtr.
+func Uint8FromPtrs(ps []*uint8) []Uint8 {
+	return fromPtrs(ps, Uint8FromPtr)
+}
+
+// Uint8Ptrs converts each element of vs using Ptr method.
+func Uint8Ptrs(vs []Uint8) []*uint8 {
+	return ptrs(vs, (*Uint8).Ptr)
+}
+
+// Uint16FromPtr returns Uint16 holding value pointed by p.
+func Uint16FromPtr(p *uint16) Uint16 {
+	v := FromPtr(p)
+	return Uint16{Uint16: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (u *Uint16) Ptr() *uint16 {
+	return u.ref().ptr()
+}
+
+// Uint16FromPtrs converts each element of ps using Uint16FromPtr.
+func Uint16FromPtrs(ps []*uint16) []Uint16 {
+	return fromPtrs(ps, Uint16FromPtr)
+}
+
+// Uint16Ptrs converts each element of vs using Ptr method.
+func Uint16Ptrs(vs []Uint16) []*uint16 {
+	return ptrs(vs, (*Uint16).Ptr)
+}
+
+// Uint32FromPtr returns Uint32 holding value pointed by p.
+func Uint32FromPtr(p *uint32) Uint32 {
+	v := FromPtr(p)
+	return Uint32{Uint32: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (u *Uint32) Ptr() *uint32 {
+	return u.ref().ptr()
+}
+
+// Uint32FromPtrs converts each element of ps using Uint32FromPtr.
+func Uint32FromPtrs(ps []*uint32) []Uint32 {
+	return fromPtrs(ps, Uint32FromPtr)
+}
+
+// Uint32Ptrs converts each element of vs using Ptr method.
+func Uint32Ptrs(vs []Uint32) []*uint32 {
+	return ptrs(vs, (*Uint32).Ptr)
+}
+
+// Uint64FromPtr returns Uint64 holding value pointed by p.
+func Uint64FromPtr(p *uint64) Uint64 {
+	v := FromPtr(p)
+	return Uint64{Uint64: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (u *Uint64) Ptr() *uint64 {
+	return u.ref().ptr()
+}
+
+// Uint64FromPtrs converts each element of ps using Uint64FromPtr.
+func Uint64FromPtrs(ps []*uint64) []Uint64 {
+	return fromPtrs(ps, Uint64FromPtr)
+}
+
+// Uint64Ptrs converts each element of vs using Ptr method.
+func Uint64Ptrs(vs []Uint64) []*uint64 {
+	return ptrs(vs, (*Uint64).Ptr)
+}
+
+// Float32FromPtr returns Float32 holding value pointed by p.
+func Float32FromPtr(p *float32) Float32 {
+	v := FromPtr(p)
+	return Float32{Float32: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (f *Float32) Ptr() *float32 {
+	return f.ref().ptr()
+}
+
+// Float32FromPtrs converts each element of ps using Float32FromPtr.
+func Float32FromPtrs(ps []*float32) []Float32 {
+	return fromPtrs(ps, Float32FromPtr)
+}
+
+// Float32Ptrs converts each element of vs using Ptr method.
+func Float32Ptrs(vs []Float32) []*float32 {
+	return ptrs(vs, (*Float32).Ptr)
+}
+
+// Float64FromPtr returns Float64 holding value pointed by p.
+func Float64FromPtr(p *float64) Float64 {
+	v := FromPtr(p)
+	return Float64{Float64: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (f *Float64) Ptr() *float64 {
+	return f.ref().ptr()
+}
+
+// Float64FromPtrs converts each element of ps using Float64FromPtr.
+func Float64FromPtrs(ps []*float64) []Float64 {
+	return fromPtrs(ps, Float64FromPtr)
+}
+
+// Float64Ptrs converts each element of vs using Ptr method.
+func Float64Ptrs(vs []Float64) []*float64 {
+	return ptrs(vs, (*Float64).Ptr)
+}
+
+// BoolFromPtr returns Bool holding value pointed by p.
+func BoolFromPtr(p *bool) Bool {
+	v := FromPtr(p)
+	return Bool{Bool: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (b *Bool) Ptr() *bool {
+	return b.ref().ptr()
+}
+
+// BoolFromPtrs converts each element of ps using BoolFromPtr.
+func BoolFromPtrs(ps []*bool) []Bool {
+	return fromPtrs(ps, BoolFromPtr)
+}
+
+// BoolPtrs converts each element of vs using Ptr method.
+func BoolPtrs(vs []Bool) []*bool {
+	return ptrs(vs, (*Bool).Ptr)
+}
+
+// BytesFromPtr returns Bytes holding value pointed by p.
+func BytesFromPtr(p *[]byte) Bytes {
+	v := FromPtr(p)
+	return Bytes{Bytes: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (b *Bytes) Ptr() *[]byte {
+	return b.ref().ptr()
+}
+
+// BytesFromPtrs converts each element of ps using BytesFromPtr.
+func BytesFromPtrs(ps []*[]byte) []Bytes {
+	return fromPtrs(ps, BytesFromPtr)
+}
+
+// BytesPtrs converts each element of vs using Ptr method.
+func BytesPtrs(vs []Bytes) []*[]byte {
+	return ptrs(vs, (*Bytes).Ptr)
+}
+
+// TimeFromPtr returns Time holding value pointed by p.
+func TimeFromPtr(p *time.Time) Time {
+	v := FromPtr(p)
+	return Time{Time: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (t *Time) Ptr() *time.Time {
+	return t.ref().ptr()
+}
+
+// TimeFromPtrs converts each element of ps using TimeFromPtr.
+func TimeFromPtrs(ps []*time.Time) []Time {
+	return fromPtrs(ps, TimeFromPtr)
+}
+
+// TimePtrs converts each element of vs using Ptr method.
+func TimePtrs(vs []Time) []*time.Time {
+	return ptrs(vs, (*Time).Ptr)
+}
+
+// DurationFromPtr returns Duration holding value pointed by p.
+func DurationFromPtr(p *time.Duration) Duration {
+	v := FromPtr(p)
+	return Duration{Duration: v.V, Valid: v.Valid}
+}
+
+// Ptr returns pointer to a copy of the value, or nil if receiver is nil or invalid.
+func (d *Duration) Ptr() *time.Duration {
+	return d.ref().ptr()
+}
+
+// DurationFromPtrs converts each element of ps using DurationFromPtr.
+func DurationFromPtrs(ps []*time.Duration) []Duration {
+	return fromPtrs(ps, DurationFromPtr)
+}
+
+// DurationPtrs converts each element of vs using Ptr method.
+func DurationPtrs(vs []Duration) []*time.Duration {
+	return ptrs(vs, (*Duration).Ptr)
+}
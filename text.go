@@ -0,0 +1,110 @@
+package nilt
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"time"
+)
+
+func (r ref[T]) marshalText() ([]byte, error) {
+	if !r.ok() {
+		return nil, nil
+	}
+
+	return r.appendText(nil)
+}
+
+func (r ref[T]) unmarshalText(text []byte) error {
+	if len(text) == 0 {
+		r.reset()
+		return nil
+	}
+
+	if p, ok := any(r.v).(*[]byte); ok {
+		b, err := base64.StdEncoding.AppendDecode([]byte{}, text)
+		if err != nil {
+			r.reset()
+			return err
+		}
+		*p, *r.valid = b, true
+		return nil
+	}
+
+	return r.scan(string(text))
+}
+
+// appendText appends text representation of the value to b, validity is not checked.
+// Text representation of every type is accepted by scan.
+func (r ref[T]) appendText(b []byte) ([]byte, error) {
+	switch v := any(*r.v).(type) {
+	case string:
+		return append(b, v...), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, v, 10), nil
+	case float32:
+		return appendFloat(b, float64(v), 32), nil
+	case float64:
+		return appendFloat(b, v, 64), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case time.Time:
+		return v.AppendText(b)
+	case time.Duration:
+		return append(b, v.String()...), nil
+	case []byte:
+		return base64.StdEncoding.AppendEncode(b, v), nil
+	}
+
+	return b, nil
+}
+
+// appendQuotedText appends text representation of the value to b surrounded by double quotes.
+// It is used only for types, for which text representation never has to be escaped in JSON.
+func (r ref[T]) appendQuotedText(b []byte) ([]byte, error) {
+	b, err := r.appendText(append(b, '"'))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '"'), nil
+}
+
+// appendFloat formats f the same way as encoding/json does,
+// using exponent only for very small and very large numbers.
+func appendFloat(b []byte, f float64, bitSize int) []byte {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) || bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bitSize)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,141 @@
+package nilt
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Time represents a time.Time that may be nil.
+// On the wire it is compatible with the Time message from nilt.proto,
+// which wraps google.protobuf.Timestamp.
+type Time struct {
+	Time  time.Time `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	Valid bool      `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+}
+
+// Reset implements proto.Message interface.
+func (t *Time) Reset() { *t = Time{} }
+
+// String implements proto.Message interface.
+func (t *Time) String() string { return compactText(t.ProtoReflect()) }
+
+// ProtoMessage implements proto.Message interface.
+func (*Time) ProtoMessage() {}
+
+// ProtoReflect implements protoreflect.ProtoMessage interface.
+func (t *Time) ProtoReflect() protoreflect.Message { return timeType.message(t) }
+
+func (t *Time) ref() ref[time.Time] {
+	if t == nil {
+		return ref[time.Time]{}
+	}
+	return ref[time.Time]{v: &t.Time, valid: &t.Valid}
+}
+
+func (t *Time) unwrap() (interface{}, bool) {
+	return t.ref().unwrap()
+}
+
+// TimeOr returns given time.Time value if receiver is nil or invalid.
+func (t *Time) TimeOr(or time.Time) time.Time {
+	return t.ref().or(or)
+}
+
+// Value implements the driver Valuer interface.
+func (t Time) Value() (driver.Value, error) {
+	return t.ref().value()
+}
+
+// Scan implements the Scanner interface.
+// Besides time.Time it accepts RFC3339 formatted string and []byte.
+func (t *Time) Scan(value interface{}) error {
+	return t.ref().scan(value)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Valid time is encoded as RFC3339Nano string.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return t.ref().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return t.ref().unmarshalJSON(data)
+}
+
+// AppendJSON appends the JSON encoding of the value to dst, the same as returned by MarshalJSON.
+func (t Time) AppendJSON(dst []byte) ([]byte, error) {
+	return t.ref().appendJSON(dst)
+}
+
+// IsZero reports whether the value is invalid.
+func (t Time) IsZero() bool {
+	return t.ref().isZero()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// Invalid value is encoded as empty text.
+func (t Time) MarshalText() ([]byte, error) {
+	return t.ref().marshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Empty text is decoded as invalid value.
+func (t *Time) UnmarshalText(text []byte) error {
+	return t.ref().unmarshalText(text)
+}
+
+// MarshalXML implements xml.Marshaler interface.
+// Invalid value is omitted or, if XMLNil is true, encoded as an empty element with xsi:nil="true" attribute.
+func (t Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return t.ref().marshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (t *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return t.ref().unmarshalXML(d, start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr interface.
+// Invalid value is omitted.
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return t.ref().marshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr interface.
+func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.ref().unmarshalXMLAttr(attr)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (t Time) MarshalBinary() ([]byte, error) {
+	return t.ref().appendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender interface.
+func (t Time) AppendBinary(b []byte) ([]byte, error) {
+	return t.ref().appendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	return t.ref().unmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (t Time) GobEncode() ([]byte, error) {
+	return t.ref().gobEncode()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (t *Time) GobDecode(data []byte) error {
+	return t.ref().gobDecode(data)
+}
+
+// Appear implements pqcomp Appearer interface.
+func (t *Time) Appear() bool {
+	return t.ref().appear()
+}
@@ -0,0 +1,20 @@
+package nilt
+
+// unwrapper is implemented by pointers to every type of the package.
+type unwrapper interface {
+	unwrap() (interface{}, bool)
+}
+
+// Unwrap returns the value held by v and tells if it is valid, v has to be a pointer to one of the package types.
+// The value is returned even if it is invalid or v is nil, so its dynamic type is always the type of the value,
+// e.g. int64 for *Int64, which lets other encoders handle every type in the same way.
+// It returns ok equal to false if v is not a pointer to any of the package types.
+func Unwrap(v interface{}) (value interface{}, valid, ok bool) {
+	u, ok := v.(unwrapper)
+	if !ok {
+		return nil, false, false
+	}
+
+	value, valid = u.unwrap()
+	return value, valid, true
+}
@@ -0,0 +1,157 @@
+package nilt
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Conversions between the nilt types and their counterparts among google.protobuf wrapper and well-known types,
+// commonly used for optional fields. Nil message maps to invalid value and vice versa.
+
+// StringFromProto returns String holding value of given google.protobuf.StringValue.
+func StringFromProto(v *wrapperspb.StringValue) String {
+	return String{String: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.StringValue holding the value, or nil if receiver is nil or invalid.
+func (s *String) ToProto() *wrapperspb.StringValue {
+	if !s.ref().ok() {
+		return nil
+	}
+	return wrapperspb.String(s.String)
+}
+
+// Int32FromProto returns Int32 holding value of given google.protobuf.Int32Value.
+func Int32FromProto(v *wrapperspb.Int32Value) Int32 {
+	return Int32{Int32: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.Int32Value holding the value, or nil if receiver is nil or invalid.
+func (i *Int32) ToProto() *wrapperspb.Int32Value {
+	if !i.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Int32(i.Int32)
+}
+
+// Int64FromProto returns Int64 holding value of given google.protobuf.Int64Value.
+func Int64FromProto(v *wrapperspb.Int64Value) Int64 {
+	return Int64{Int64: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.Int64Value holding the value, or nil if receiver is nil or invalid.
+func (i *Int64) ToProto() *wrapperspb.Int64Value {
+	if !i.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Int64(i.Int64)
+}
+
+// Uint32FromProto returns Uint32 holding value of given google.protobuf.UInt32Value.
+func Uint32FromProto(v *wrapperspb.UInt32Value) Uint32 {
+	return Uint32{Uint32: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.UInt32Value holding the value, or nil if receiver is nil or invalid.
+func (u *Uint32) ToProto() *wrapperspb.UInt32Value {
+	if !u.ref().ok() {
+		return nil
+	}
+	return wrapperspb.UInt32(u.Uint32)
+}
+
+// Uint64FromProto returns Uint64 holding value of given google.protobuf.UInt64Value.
+func Uint64FromProto(v *wrapperspb.UInt64Value) Uint64 {
+	return Uint64{Uint64: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.UInt64Value holding the value, or nil if receiver is nil or invalid.
+func (u *Uint64) ToProto() *wrapperspb.UInt64Value {
+	if !u.ref().ok() {
+		return nil
+	}
+	return wrapperspb.UInt64(u.Uint64)
+}
+
+// Float32FromProto returns Float32 holding value of given google.protobuf.FloatValue.
+func Float32FromProto(v *wrapperspb.FloatValue) Float32 {
+	return Float32{Float32: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.FloatValue holding the value, or nil if receiver is nil or invalid.
+func (f *Float32) ToProto() *wrapperspb.FloatValue {
+	if !f.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Float(f.Float32)
+}
+
+// Float64FromProto returns Float64 holding value of given google.protobuf.DoubleValue.
+func Float64FromProto(v *wrapperspb.DoubleValue) Float64 {
+	return Float64{Float64: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.DoubleValue holding the value, or nil if receiver is nil or invalid.
+func (f *Float64) ToProto() *wrapperspb.DoubleValue {
+	if !f.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Double(f.Float64)
+}
+
+// BoolFromProto returns Bool holding value of given google.protobuf.BoolValue.
+func BoolFromProto(v *wrapperspb.BoolValue) Bool {
+	return Bool{Bool: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.BoolValue holding the value, or nil if receiver is nil or invalid.
+func (b *Bool) ToProto() *wrapperspb.BoolValue {
+	if !b.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Bool(b.Bool)
+}
+
+// BytesFromProto returns Bytes holding value of given google.protobuf.BytesValue.
+func BytesFromProto(v *wrapperspb.BytesValue) Bytes {
+	return Bytes{Bytes: v.GetValue(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.BytesValue holding the value, or nil if receiver is nil or invalid.
+func (b *Bytes) ToProto() *wrapperspb.BytesValue {
+	if !b.ref().ok() {
+		return nil
+	}
+	return wrapperspb.Bytes(b.Bytes)
+}
+
+// TimeFromProto returns Time holding value of given google.protobuf.Timestamp.
+func TimeFromProto(v *timestamppb.Timestamp) Time {
+	if v == nil {
+		return Time{}
+	}
+	return Time{Time: v.AsTime(), Valid: true}
+}
+
+// ToProto returns google.protobuf.Timestamp holding the value, or nil if receiver is nil or invalid.
+func (t *Time) ToProto() *timestamppb.Timestamp {
+	if !t.ref().ok() {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// DurationFromProto returns Duration holding value of given google.protobuf.Duration.
+// Duration out of range of time.Duration is truncated to its minimum or maximum.
+func DurationFromProto(v *durationpb.Duration) Duration {
+	return Duration{Duration: v.AsDuration(), Valid: v != nil}
+}
+
+// ToProto returns google.protobuf.Duration holding the value, or nil if receiver is nil or invalid.
+func (d *Duration) ToProto() *durationpb.Duration {
+	if !d.ref().ok() {
+		return nil
+	}
+	return durationpb.New(d.Duration)
+}
@@ -0,0 +1,85 @@
+package nilt
+
+import (
+	"encoding/xml"
+)
+
+// XMLNil, if true, makes MarshalXML encode invalid values as empty elements with xsi:nil="true" attribute.
+// Otherwise invalid values are omitted.
+// UnmarshalXML decodes elements with xsi:nil="true" attribute as invalid regardless of it.
+var XMLNil = false
+
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+func (r ref[T]) marshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !r.ok() {
+		if !XMLNil {
+			return nil
+		}
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	text, err := r.appendText(nil)
+	if err != nil {
+		return err
+	}
+
+	return e.EncodeElement(string(text), start)
+}
+
+func (r ref[T]) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == xsiNamespace && attr.Name.Local == "nil" && (attr.Value == "true" || attr.Value == "1") {
+			r.reset()
+			return d.Skip()
+		}
+	}
+
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	return r.unmarshalXMLText(s)
+}
+
+func (r ref[T]) marshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !r.ok() {
+		return xml.Attr{}, nil
+	}
+
+	text, err := r.appendText(nil)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+func (r ref[T]) unmarshalXMLAttr(attr xml.Attr) error {
+	return r.unmarshalXMLText(attr.Value)
+}
+
+// unmarshalXMLText decodes content of an element or an attribute.
+// Empty content is a valid empty string or slice, for other types it means invalid value.
+func (r ref[T]) unmarshalXMLText(s string) error {
+	if s == "" {
+		switch p := any(r.v).(type) {
+		case *string:
+			*p, *r.valid = "", true
+			return nil
+		case *[]byte:
+			*p, *r.valid = []byte{}, true
+			return nil
+		}
+	}
+
+	return r.unmarshalText([]byte(s))
+}
@@ -0,0 +1,12 @@
+module github.com/piotrkowalczuk/nilt/yamlx
+
+go 1.24
+
+require (
+	github.com/piotrkowalczuk/nilt v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require google.golang.org/protobuf v1.36.9 // indirect
+
+replace github.com/piotrkowalczuk/nilt => ../
@@ -0,0 +1,372 @@
+// Package yamlx provides YAML support for nilt types.
+// It is a separate package, so that gopkg.in/yaml.v3 is required only by programs that use it.
+//
+// Each type of the package embeds its nilt counterpart and implements yaml.Marshaler and yaml.Unmarshaler.
+// Invalid value is encoded as null, both null and ~ are decoded as invalid value,
+// while absent key leaves the field untouched.
+package yamlx
+
+import (
+	"encoding"
+	"fmt"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	nullTag      = "!!null"
+	strTag       = "!!str"
+	intTag       = "!!int"
+	floatTag     = "!!float"
+	boolTag      = "!!bool"
+	timestampTag = "!!timestamp"
+	binaryTag    = "!!binary"
+)
+
+type nullable interface {
+	encoding.TextMarshaler
+	Appear() bool
+}
+
+// Of wraps nilt.Of with YAML support.
+type Of[T nilt.Scalar] struct {
+	nilt.Of[T]
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (o Of[T]) MarshalYAML() (interface{}, error) {
+	return marshal(&o.Of, tagOf[T]())
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (o *Of[T]) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &o.Of.V, &o.Of.Valid)
+}
+
+// String wraps nilt.String with YAML support.
+type String struct {
+	nilt.String
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (s String) MarshalYAML() (interface{}, error) {
+	return marshal(&s.String, strTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (s *String) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &s.String.String, &s.String.Valid)
+}
+
+// Int wraps nilt.Int with YAML support.
+type Int struct {
+	nilt.Int
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (i Int) MarshalYAML() (interface{}, error) {
+	return marshal(&i.Int, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (i *Int) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &i.Int.Int, &i.Int.Valid)
+}
+
+// Int8 wraps nilt.Int8 with YAML support.
+type Int8 struct {
+	nilt.Int8
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (i Int8) MarshalYAML() (interface{}, error) {
+	return marshal(&i.Int8, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (i *Int8) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &i.Int8.Int8, &i.Int8.Valid)
+}
+
+// Int16 wraps nilt.Int16 with YAML support.
+type Int16 struct {
+	nilt.Int16
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (i Int16) MarshalYAML() (interface{}, error) {
+	return marshal(&i.Int16, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (i *Int16) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &i.Int16.Int16, &i.Int16.Valid)
+}
+
+// Int32 wraps nilt.Int32 with YAML support.
+type Int32 struct {
+	nilt.Int32
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (i Int32) MarshalYAML() (interface{}, error) {
+	return marshal(&i.Int32, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (i *Int32) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &i.Int32.Int32, &i.Int32.Valid)
+}
+
+// Int64 wraps nilt.Int64 with YAML support.
+type Int64 struct {
+	nilt.Int64
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	return marshal(&i.Int64, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (i *Int64) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &i.Int64.Int64, &i.Int64.Valid)
+}
+
+// Uint wraps nilt.Uint with YAML support.
+type Uint struct {
+	nilt.Uint
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (u Uint) MarshalYAML() (interface{}, error) {
+	return marshal(&u.Uint, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (u *Uint) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &u.Uint.Uint, &u.Uint.Valid)
+}
+
+// Uint8 wraps nilt.Uint8 with YAML support.
+type Uint8 struct {
+	nilt.Uint8
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (u Uint8) MarshalYAML() (interface{}, error) {
+	return marshal(&u.Uint8, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (u *Uint8) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &u.Uint8.Uint8, &u.Uint8.Valid)
+}
+
+// Uint16 wraps nilt.Uint16 with YAML support.
+type Uint16 struct {
+	nilt.Uint16
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (u Uint16) MarshalYAML() (interface{}, error) {
+	return marshal(&u.Uint16, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (u *Uint16) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &u.Uint16.Uint16, &u.Uint16.Valid)
+}
+
+// Uint32 wraps nilt.Uint32 with YAML support.
+type Uint32 struct {
+	nilt.Uint32
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (u Uint32) MarshalYAML() (interface{}, error) {
+	return marshal(&u.Uint32, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (u *Uint32) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &u.Uint32.Uint32, &u.Uint32.Valid)
+}
+
+// Uint64 wraps nilt.Uint64 with YAML support.
+type Uint64 struct {
+	nilt.Uint64
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	return marshal(&u.Uint64, intTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (u *Uint64) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &u.Uint64.Uint64, &u.Uint64.Valid)
+}
+
+// Float32 wraps nilt.Float32 with YAML support.
+type Float32 struct {
+	nilt.Float32
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (f Float32) MarshalYAML() (interface{}, error) {
+	return marshal(&f.Float32, floatTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (f *Float32) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &f.Float32.Float32, &f.Float32.Valid)
+}
+
+// Float64 wraps nilt.Float64 with YAML support.
+type Float64 struct {
+	nilt.Float64
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	return marshal(&f.Float64, floatTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (f *Float64) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &f.Float64.Float64, &f.Float64.Valid)
+}
+
+// Bool wraps nilt.Bool with YAML support.
+type Bool struct {
+	nilt.Bool
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	return marshal(&b.Bool, boolTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (b *Bool) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &b.Bool.Bool, &b.Bool.Valid)
+}
+
+// Time wraps nilt.Time with YAML support.
+type Time struct {
+	nilt.Time
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (t Time) MarshalYAML() (interface{}, error) {
+	return marshal(&t.Time, timestampTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (t *Time) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &t.Time.Time, &t.Time.Valid)
+}
+
+// Duration wraps nilt.Duration with YAML support.
+type Duration struct {
+	nilt.Duration
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return marshal(&d.Duration, strTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &d.Duration.Duration, &d.Duration.Valid)
+}
+
+// Bytes wraps nilt.Bytes with YAML support.
+type Bytes struct {
+	nilt.Bytes
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (b Bytes) MarshalYAML() (interface{}, error) {
+	return marshal(&b.Bytes, binaryTag)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (b *Bytes) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshal(value, &b.Bytes.Bytes, &b.Bytes.Valid)
+}
+
+func marshal(n nullable, tag string) (interface{}, error) {
+	if !n.Appear() {
+		return nil, nil
+	}
+
+	text, err := n.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(text)}
+	if tag == floatTag {
+		switch node.Value {
+		case "NaN":
+			node.Value = ".nan"
+		case "+Inf":
+			node.Value = ".inf"
+		case "-Inf":
+			node.Value = "-.inf"
+		}
+	}
+
+	return node, nil
+}
+
+// unmarshal decodes given node into v, using the same tag resolution as for T alone.
+// Only null is handled here, it resets the value and marks it invalid.
+func unmarshal[T nilt.Scalar](value *yaml.Node, v *T, valid *bool) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("yamlx: cannot unmarshal %s into %T", value.ShortTag(), v)
+	}
+	if value.ShortTag() == nullTag {
+		var zero T
+		*v, *valid = zero, false
+		return nil
+	}
+
+	var decoded T
+	if p, ok := any(&decoded).(*[]byte); ok {
+		// yaml.v3 decodes !!binary into a string only.
+		var s string
+		if err := value.Decode(&s); err != nil {
+			return err
+		}
+		*p = []byte(s)
+	} else if err := value.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*v, *valid = decoded, true
+	return nil
+}
+
+func tagOf[T nilt.Scalar]() string {
+	var zero T
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return intTag
+	case float32, float64:
+		return floatTag
+	case bool:
+		return boolTag
+	case time.Time:
+		return timestampTag
+	case []byte:
+		return binaryTag
+	}
+
+	return strTag
+}
@@ -0,0 +1,152 @@
+package yamlx_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/nilt"
+	"github.com/piotrkowalczuk/nilt/yamlx"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Name           yamlx.String   `yaml:"name"`
+	MaxConnections yamlx.Int64    `yaml:"max_connections"`
+	Ratio          yamlx.Float64  `yaml:"ratio"`
+	Debug          *yamlx.Bool    `yaml:"debug"`
+	Port           yamlx.Uint16   `yaml:"port"`
+	Timeout        yamlx.Duration `yaml:"timeout"`
+	Since          yamlx.Time     `yaml:"since"`
+	Secret         yamlx.Bytes    `yaml:"secret"`
+	Limit          yamlx.Of[int]  `yaml:"limit"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	given := `
+name: ""
+max_connections: null
+ratio: 0
+debug: ~
+port: 8080
+timeout: 1m30s
+since: 2016-04-24T12:30:15Z
+secret: !!binary dGV4dA==
+`
+	var got config
+	got.Limit.V, got.Limit.Valid = 5, true
+	if err := yaml.Unmarshal([]byte(given), &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !got.Name.Valid || got.Name.String.String != "" {
+		t.Errorf("name: expected valid empty string, got %#v", got.Name)
+	}
+	if got.MaxConnections.Valid {
+		t.Errorf("max_connections: expected invalid value, got %#v", got.MaxConnections)
+	}
+	if !got.Ratio.Valid || got.Ratio.Float64.Float64 != 0 {
+		t.Errorf("ratio: expected valid zero, got %#v", got.Ratio)
+	}
+	if got.Debug != nil {
+		t.Errorf("debug: expected nil pointer, got %#v", got.Debug)
+	}
+	if !got.Port.Valid || got.Port.Uint16.Uint16 != 8080 {
+		t.Errorf("port: expected 8080, got %#v", got.Port)
+	}
+	if !got.Timeout.Valid || got.Timeout.Duration.Duration != 90*time.Second {
+		t.Errorf("timeout: expected 1m30s, got %#v", got.Timeout)
+	}
+	if !got.Since.Valid || !got.Since.Time.Time.Equal(time.Date(2016, 4, 24, 12, 30, 15, 0, time.UTC)) {
+		t.Errorf("since: wrong value, got %#v", got.Since)
+	}
+	if !got.Secret.Valid || string(got.Secret.Bytes.Bytes) != "text" {
+		t.Errorf("secret: wrong value, got %#v", got.Secret)
+	}
+	if !got.Limit.Valid || got.Limit.V != 5 {
+		t.Errorf("limit: expected absent key to leave the value untouched, got %#v", got.Limit)
+	}
+}
+
+func TestUnmarshal_resolution(t *testing.T) {
+	given := `
+max_connections: 0x10
+ratio: .NaN
+since: 2001-12-14
+port: 0o17
+`
+	var got config
+	if err := yaml.Unmarshal([]byte(given), &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !got.MaxConnections.Valid || got.MaxConnections.Int64.Int64 != 16 {
+		t.Errorf("max_connections: expected 16, got %#v", got.MaxConnections)
+	}
+	if !got.Ratio.Valid || !math.IsNaN(got.Ratio.Float64.Float64) {
+		t.Errorf("ratio: expected NaN, got %#v", got.Ratio)
+	}
+	if !got.Since.Valid || !got.Since.Time.Time.Equal(time.Date(2001, 12, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("since: wrong value, got %#v", got.Since)
+	}
+	if !got.Port.Valid || got.Port.Uint16.Uint16 != 15 {
+		t.Errorf("port: expected 15, got %#v", got.Port)
+	}
+}
+
+func TestUnmarshal_error(t *testing.T) {
+	cases := map[string]string{
+		"out of range": "port: 65536",
+		"not a number": "max_connections: many",
+		"sequence":     "name: [a, b]",
+		"quoted int":   `max_connections: "12"`,
+		"int as bool":  "debug: 1",
+	}
+
+	for d, given := range cases {
+		var got config
+		if err := yaml.Unmarshal([]byte(given), &got); err == nil {
+			t.Errorf("%s: expected error", d)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	given := config{
+		Name:           yamlx.String{String: nilt.String{String: "123", Valid: true}},
+		MaxConnections: yamlx.Int64{Int64: nilt.Int64{Int64: 0, Valid: true}},
+		Ratio:          yamlx.Float64{Float64: nilt.Float64{Float64: math.Inf(-1), Valid: true}},
+		Port:           yamlx.Uint16{Uint16: nilt.Uint16{}},
+		Timeout:        yamlx.Duration{Duration: nilt.Duration{Duration: time.Minute, Valid: true}},
+		Secret:         yamlx.Bytes{Bytes: nilt.Bytes{Bytes: []byte("text"), Valid: true}},
+		Limit:          yamlx.Of[int]{Of: nilt.Of[int]{V: 7, Valid: true}},
+	}
+
+	b, err := yaml.Marshal(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `name: "123"
+max_connections: 0
+ratio: -.inf
+debug: null
+port: null
+timeout: 1m0s
+since: null
+secret: !!binary dGV4dA==
+limit: 7
+`
+	if string(b) != expected {
+		t.Errorf("wrong output, expected:\n%s\nbut got:\n%s", expected, string(b))
+	}
+
+	var got config
+	if err = yaml.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("wrong output, expected %#v but got %#v", given, got)
+	}
+}